@@ -0,0 +1,266 @@
+// 	mnemonic-embed - builds the lexicon embedded in mnemonic
+// 	Copyright (C) 2017 Billie Alice Thompson
+//
+// 	This program is free software: you can redistribute it and/or modify
+// 	it under the terms of the GNU General Public License as published by
+// 	the Free Software Foundation, either version 3 of the License, or
+// 	(at your option) any later version.
+//
+// 	This program is distributed in the hope that it will be useful,
+// 	but WITHOUT ANY WARRANTY; without even the implied warranty of
+// 	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// 	GNU General Public License for more details.
+//
+// 	You should have received a copy of the GNU General Public License
+// 	along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/purplebooth/mnemonic/mnemonic"
+	"github.com/urfave/cli"
+)
+
+const (
+	// ErrorExitCodeUsage is the exit code when the flags given don't make sense
+	ErrorExitCodeUsage = 1 << iota
+	// ErrorExitCodeRead is the exit code when the words can't be read
+	ErrorExitCodeRead
+	// ErrorExitCodeWrite is the exit code when the Go file can't be written
+	ErrorExitCodeWrite
+)
+
+// chunkSize is how many bytes of compressed data go on each line of the generated file
+const chunkSize = 48
+
+func main() {
+	app := cli.NewApp()
+	app.Version = "v0.1.0"
+	app.Name = "mnemonic-embed"
+	app.Usage = "Build the lexicon embedded in mnemonic"
+	app.Description = "Compresses a word list, or a selection of words from a WordNet dictionary, into a Go file"
+	app.Flags = []cli.Flag{
+		cli.StringFlag{
+			Name:  "words",
+			Usage: "File of lines of a part of speech, a word, and optionally a domain, synset id and gloss, separated by tabs",
+		},
+		cli.StringFlag{
+			Name:  "dict",
+			Usage: "WordNet dictionary to pick words from instead",
+		},
+		cli.IntFlag{
+			Name:  "per-letter",
+			Value: 40,
+			Usage: "Most words to pick for each part of speech and letter from a WordNet dictionary, the most common first",
+		},
+		cli.StringFlag{
+			Name:  "o",
+			Value: "lexicon_embedded_data.go",
+			Usage: "Go file to write",
+		},
+	}
+	app.Action = func(c *cli.Context) error {
+		var lines []string
+		var err error
+
+		switch {
+		case c.String("words") != "":
+			lines, err = readLines(c.String("words"))
+		case c.String("dict") != "":
+			lines, err = pickWordNetLines(c.String("dict"), c.Int("per-letter"))
+		default:
+			return cli.NewExitError("one of --words or --dict is needed", ErrorExitCodeUsage)
+		}
+
+		if err != nil {
+			return cli.NewExitError(err.Error(), ErrorExitCodeRead)
+		}
+
+		err = writeGoFile(c.String("o"), lines)
+
+		if err != nil {
+			return cli.NewExitError(err.Error(), ErrorExitCodeWrite)
+		}
+
+		return nil
+	}
+
+	app.Run(os.Args)
+}
+
+// readLines returns the non blank lines in a word list
+func readLines(path string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	lines := []string{}
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		if strings.TrimSpace(scanner.Text()) != "" {
+			lines = append(lines, scanner.Text())
+		}
+	}
+
+	return lines, scanner.Err()
+}
+
+// pickWordNetLines picks the most common plain single words from a WordNet dictionary, with the domain, synset id
+// and gloss of each word's most common sense
+//
+// Slang, vulgar and offensive words are left out, like safe mode does.
+func pickWordNetLines(dictDir string, perLetter int) ([]string, error) {
+	lexicon, err := mnemonic.LoadWordNetLexicon(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lexicon = mnemonic.NewSafeContentFilter().Apply(lexicon)
+	senses := make(map[string]mnemonic.LexiconEntry)
+	pools := make(map[string][]string)
+
+	for _, entry := range lexicon.Entries() {
+		if !isPlainWord(entry.Lemma) {
+			continue
+		}
+
+		key := entry.PartOfSpeech + "\t" + entry.Lemma
+		sense, seen := senses[key]
+
+		if !seen {
+			pool := entry.PartOfSpeech + "\t" + entry.Lemma[:1]
+			pools[pool] = append(pools[pool], key)
+		}
+
+		if !seen || entry.Frequency > sense.Frequency {
+			senses[key] = entry
+		}
+	}
+
+	lines := []string{}
+
+	for _, keys := range pools {
+		sort.Slice(keys, func(i, j int) bool {
+			if senses[keys[i]].Frequency != senses[keys[j]].Frequency {
+				return senses[keys[i]].Frequency > senses[keys[j]].Frequency
+			}
+
+			return keys[i] < keys[j]
+		})
+
+		if len(keys) > perLetter {
+			keys = keys[:perLetter]
+		}
+
+		for _, key := range keys {
+			sense := senses[key]
+			lines = append(lines, strings.Join([]string{key, sense.Domain, sense.SynsetID, sense.Gloss}, "\t"))
+		}
+	}
+
+	sort.Strings(lines)
+
+	return lines, nil
+}
+
+// isPlainWord returns true for lower case words of 3 to 10 letters, leaving out phrases and abbreviations
+func isPlainWord(word string) bool {
+	if len(word) < 3 || len(word) > 10 {
+		return false
+	}
+
+	for _, letter := range word {
+		if letter > unicode.MaxASCII || !unicode.IsLower(letter) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeGoFile writes the gzipped lines as a Go string constant
+func writeGoFile(path string, lines []string) error {
+	compressed := &bytes.Buffer{}
+	writer, err := gzip.NewWriterLevel(compressed, gzip.BestCompression)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = writer.Write([]byte(strings.Join(lines, "\n") + "\n"))
+
+	if err != nil {
+		return err
+	}
+
+	err = writer.Close()
+
+	if err != nil {
+		return err
+	}
+
+	data := compressed.Bytes()
+	source := &bytes.Buffer{}
+
+	fmt.Fprintln(source, "// Code generated by mnemonic-embed; DO NOT EDIT.")
+	fmt.Fprintln(source)
+	fmt.Fprintln(source, "//go:build !noembed")
+	fmt.Fprintln(source, "// +build !noembed")
+	fmt.Fprintln(source)
+	fmt.Fprintln(source, "package mnemonic")
+	fmt.Fprintln(source)
+	fmt.Fprintf(
+		source,
+		"// embeddedLexiconData is %d words, gzipped lines of a part of speech, a word, and its domain, synset id and "+
+			"gloss when known\n",
+		len(lines),
+	)
+	fmt.Fprint(source, "const embeddedLexiconData = \"\" +")
+
+	for i := 0; i < len(data); i += chunkSize {
+		end := i + chunkSize
+
+		if end > len(data) {
+			end = len(data)
+		}
+
+		separator := " +"
+
+		if end == len(data) {
+			separator = ""
+		}
+
+		fmt.Fprintf(source, "\n\t\"%s\"%s", hexEscape(data[i:end]), separator)
+	}
+
+	fmt.Fprintln(source)
+
+	return ioutil.WriteFile(path, source.Bytes(), 0644)
+}
+
+// hexEscape writes every byte as a \x escape, so the file is plain ASCII whatever the data
+func hexEscape(data []byte) string {
+	escaped := &bytes.Buffer{}
+
+	for _, b := range data {
+		fmt.Fprintf(escaped, "\\x%02x", b)
+	}
+
+	return escaped.String()
+}
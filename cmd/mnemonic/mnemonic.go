@@ -17,13 +17,14 @@
 package main
 
 import (
-	"bufio"
+	"context"
 	"fmt"
-	"log"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/lloyd/wnram"
 	"github.com/purplebooth/mnemonic/mnemonic"
 	"github.com/urfave/cli"
 )
@@ -33,6 +34,10 @@ const (
 	ErrorExitCodeWordNet = 1 << iota
 	// ErrorExitCodeTemplateParseError is the exit code for a template parse error
 	ErrorExitCodeTemplateParseError
+	// ErrorExitCodeNoWord is the exit code when a letter has no word to stand for it
+	ErrorExitCodeNoWord
+	// ErrorExitCodeUsage is the exit code when the flags given don't make sense
+	ErrorExitCodeUsage
 )
 
 func main() {
@@ -54,47 +59,218 @@ func main() {
 	`
 	app.Commands = []cli.Command{
 		{
-			Name:        "generate",
-			ArgsUsage:   "[PATH-TO-DICTIONARY] [LETTERS]",
-			Usage:       "Generate a mnemonic from a string of characters (Default)",
-			Description: "Generate a mnemonic from a string of characters",
+			Name:      "generate",
+			ArgsUsage: "[PATH-TO-DICTIONARY[=WEIGHT]...] LETTERS",
+			Usage:     "Generate a mnemonic from a string of characters (Default)",
+			Description: "Generate a mnemonic from a string of characters\n\n" +
+				"   Give more than one dictionary to merge them, a word is taken from the first one that has it. " +
+				"Add =WEIGHT to a dictionary to make its words more or less likely.",
+			Flags: []cli.Flag{
+				cli.Int64Flag{
+					Name:  "seed",
+					Usage: "Seed for the random word choice, the same seed gives the same mnemonic (Default: random)",
+				},
+				cli.IntFlag{
+					Name:  "count",
+					Value: 1,
+					Usage: "Number of different mnemonics to generate, listed so you can pick one",
+				},
+				cli.StringFlag{
+					Name:  "style",
+					Value: string(mnemonic.StyleProse),
+					Usage: "How to lay the mnemonic out, one of prose, sentence, headline, story, list or poem",
+				},
+				cli.IntFlag{
+					Name:  "min-words",
+					Value: mnemonic.DefaultMinWords,
+					Usage: "Fewest words in a sentence of prose",
+				},
+				cli.IntFlag{
+					Name:  "max-words",
+					Value: mnemonic.DefaultMaxWords,
+					Usage: "Most words in a sentence of prose",
+				},
+				cli.StringFlag{
+					Name:  "grammar",
+					Usage: "File of grammar rules to lay the mnemonic out with, in place of the style",
+				},
+				cli.StringFlag{
+					Name:  "placeholder",
+					Usage: "Text to use for letters that no word begins with (Default: fail)",
+				},
+				cli.StringFlag{
+					Name:  "index-cache",
+					Value: defaultIndexCache(),
+					Usage: "Directory to keep an index of the dictionary in so it loads faster, empty to turn off",
+				},
+				cli.StringFlag{
+					Name:  "overlay",
+					Usage: "CSV file of your own words to add, boost or suppress, with the columns action, word, pos and weight",
+				},
+				cli.Float64Flag{
+					Name:  "overlay-chance",
+					Value: 0.25,
+					Usage: "Chance of trying the overlay's words first for each letter, from 0 to 1",
+				},
+				cli.Float64Flag{
+					Name:  "commonness",
+					Value: 1,
+					Usage: "How strongly to prefer everyday words when the dictionary knows how common they are, 0 to ignore it",
+				},
+				cli.IntFlag{
+					Name:  "min-frequency",
+					Usage: "Leave out words seen fewer times than this, when the dictionary knows how common they are",
+				},
+				cli.StringFlag{
+					Name:  "frequency-list",
+					Usage: "File of words and how often they're used, one per line, to use on top of the dictionary's own counts",
+				},
+				cli.StringFlag{
+					Name:  "theme",
+					Usage: "Prefer words related to a topic, like ocean or kitchen, so the mnemonic makes a picture",
+				},
+				cli.StringFlag{
+					Name:  "domain",
+					Usage: "Only use words from these categories, comma separated, like noun.animal,noun.food",
+				},
+				cli.BoolTFlag{
+					Name:  "safe",
+					Usage: "Leave out vulgar, offensive and slang words, use --safe=false to turn off (Default: on)",
+				},
+				cli.StringFlag{
+					Name:  "blocklist",
+					Usage: "File of words to leave out, one per line",
+				},
+				cli.StringFlag{
+					Name:  "allowlist",
+					Usage: "File of words to let in even when they'd be left out, one per line",
+				},
+				cli.BoolFlag{
+					Name:  "explain",
+					Usage: "List each word on stderr with the letter it stands for and the dictionary it came from",
+				},
+				cli.StringFlag{
+					Name:  "escape",
+					Value: mnemonic.EscapePlain.String(),
+					Usage: "Escape the mnemonic for where it's going, one of plain, html, shell or json",
+				},
+			},
 
 			Action: func(c *cli.Context) error {
-				dictDir := c.Args().Get(0)
-				letters := strings.Split(strings.ToLower(c.Args().Get(1)), "")
-				template := mnemonic.NewTemplate(letters)
+				if c.NArg() == 0 {
+					return cli.NewExitError("the letters to make a mnemonic for are needed", ErrorExitCodeUsage)
+				}
 
-				// This library is bad and prints to stdout.
-				// We're swapping out stdout for a fake here then restoring it
-				rescueStdout := os.Stdout
-				_, w, _ := os.Pipe()
-				os.Stdout = w
+				seed := time.Now().UnixNano()
 
-				wn, err := wnram.New(dictDir)
+				if c.IsSet("seed") {
+					seed = c.Int64("seed")
+				}
 
-				w.Close()
-				os.Stdout = rescueStdout
+				// Report on stderr so the mnemonic can still be piped, rerun with --seed to replay it
+				fmt.Fprintf(os.Stderr, "seed: %d\n", seed)
+
+				escaping, err := mnemonic.ParseEscaping(c.String("escape"))
 
 				if err != nil {
-					log.Fatal(err)
+					return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+				}
+
+				style := mnemonic.TemplateStyle(c.String("style"))
+
+				if _, err := mnemonic.StyleGrammar(style); err != nil {
+					return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+				}
+
+				// The sentence lengths only matter for prose, and not at all with a grammar
+				if style == mnemonic.StyleProse && c.String("grammar") == "" {
+					if _, err := mnemonic.NewProseGrammar(c.Int("min-words"), c.Int("max-words")); err != nil {
+						return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+					}
+				}
+
+				// The letters come last, anything before them is a dictionary. Without one the words come from the
+				// lexicon built into the binary
+				letters := c.Args().Get(c.NArg() - 1)
+				options := []mnemonic.Option{
+					mnemonic.WithIndexCache(c.String("index-cache")),
+					mnemonic.WithSeed(seed),
+					mnemonic.WithPlaceholder(c.String("placeholder")),
+					mnemonic.WithTemplateStyle(style),
+					mnemonic.WithSentenceLength(c.Int("min-words"), c.Int("max-words")),
+					mnemonic.WithOutputFormat(escaping),
+					mnemonic.WithCommonness(c.Float64("commonness")),
+					mnemonic.WithMinFrequency(c.Int("min-frequency")),
+				}
+
+				filter, err := contentFilter(c)
+
+				if err != nil {
+					return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+				}
+
+				options = append(options, mnemonic.WithContentFilter(filter))
+
+				if c.String("grammar") != "" {
+					grammar, err := mnemonic.LoadGrammar(c.String("grammar"))
+
+					if err != nil {
+						return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+					}
 
-					return cli.NewExitError(err.Error(), ErrorExitCodeWordNet)
+					options = append(options, mnemonic.WithGrammar(grammar))
 				}
 
-				generator := mnemonic.NewTemplateParser(
-					mnemonic.NewWnramWordGenerator(wn, wnram.Adjective),
-					mnemonic.NewWnramWordGenerator(wn, wnram.Noun),
-					mnemonic.NewWnramWordGenerator(wn, wnram.Verb),
-					mnemonic.NewWnramWordGenerator(wn, wnram.Adverb),
-				)
+				if c.String("theme") != "" {
+					options = append(options, mnemonic.WithTheme(c.String("theme")))
+				}
+
+				if c.String("domain") != "" {
+					options = append(options, mnemonic.WithDomains(strings.Split(c.String("domain"), ",")...))
+				}
+
+				if c.String("frequency-list") != "" {
+					options = append(options, mnemonic.WithFrequencyList(c.String("frequency-list")))
+				}
 
-				err = generator.Parse(template, letters, bufio.NewWriter(os.Stdout))
+				for _, arg := range c.Args()[:c.NArg()-1] {
+					path, weight, err := parseDictionaryArg(arg)
 
-				fmt.Println()
+					if err != nil {
+						return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+					}
+
+					options = append(options, mnemonic.WithWeightedDictionary(path, weight))
+				}
+
+				if c.String("overlay") != "" {
+					overlay, err := mnemonic.LoadOverlay(c.String("overlay"))
+
+					if err != nil {
+						return cli.NewExitError(err.Error(), ErrorExitCodeWordNet)
+					}
+
+					options = append(options, mnemonic.WithOverlay(overlay, c.Float64("overlay-chance")))
+				}
+
+				results, err := mnemonic.GenerateAlternatives(context.Background(), letters, c.Int("count"), options...)
 
 				if err != nil {
-					log.Fatal(err)
-					return cli.NewExitError(err.Error(), ErrorExitCodeTemplateParseError)
+					return parseExitError(err)
+				}
+
+				if c.Bool("explain") {
+					explain(results)
+				}
+
+				if c.Int("count") <= 1 {
+					fmt.Println(results[0])
+
+					return nil
+				}
+
+				for i := range results {
+					fmt.Printf("%d. %s\n", i+1, results[i])
 				}
 
 				return nil
@@ -103,7 +279,103 @@ func main() {
 	}
 
 	app.Action = app.Commands[0].Action
+	app.Flags = app.Commands[0].Flags
 
 	app.EnableBashCompletion = true
 	app.Run(os.Args)
 }
+
+// parseExitError picks the exit code for an error from generating the mnemonic
+func parseExitError(err error) error {
+	switch err.(type) {
+	case *mnemonic.NoWordError:
+		return cli.NewExitError(err.Error(), ErrorExitCodeNoWord)
+	case *mnemonic.DictionaryError:
+		return cli.NewExitError(err.Error(), ErrorExitCodeWordNet)
+	case *mnemonic.DomainError:
+		return cli.NewExitError(err.Error(), ErrorExitCodeUsage)
+	}
+
+	return cli.NewExitError(err.Error(), ErrorExitCodeTemplateParseError)
+}
+
+// parseDictionaryArg splits a dictionary argument into its path and the weight after an =, which defaults to 1
+//
+// A dictionary whose own name has an = in it is used as it is, otherwise what's after the = has to be a weight
+// above 0.
+func parseDictionaryArg(arg string) (string, float64, error) {
+	separator := strings.LastIndex(arg, "=")
+
+	if separator < 1 {
+		return arg, 1, nil
+	}
+
+	if _, err := os.Stat(arg); err == nil {
+		return arg, 1, nil
+	}
+
+	weight, err := strconv.ParseFloat(arg[separator+1:], 64)
+
+	if err != nil || weight <= 0 {
+		return "", 0, fmt.Errorf("the weight of %q has to be a number above 0, not %q", arg[:separator], arg[separator+1:])
+	}
+
+	return arg[:separator], weight, nil
+}
+
+// contentFilter returns the filter for safe mode and the block and allow lists
+func contentFilter(c *cli.Context) (*mnemonic.ContentFilter, error) {
+	filter := mnemonic.NewContentFilter()
+
+	if c.BoolT("safe") {
+		filter = mnemonic.NewSafeContentFilter()
+	}
+
+	if c.String("blocklist") != "" {
+		words, err := mnemonic.LoadWordSet(c.String("blocklist"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Block(words...)
+	}
+
+	if c.String("allowlist") != "" {
+		words, err := mnemonic.LoadWordSet(c.String("allowlist"))
+
+		if err != nil {
+			return nil, err
+		}
+
+		filter.Allow(words...)
+	}
+
+	return filter, nil
+}
+
+// explain lists the words in each mnemonic on stderr, with where they came from
+func explain(results []*mnemonic.Mnemonic) {
+	for i := range results {
+		if len(results) > 1 {
+			fmt.Fprintf(os.Stderr, "%d.\n", i+1)
+		}
+
+		for _, word := range results[i].Words {
+			fmt.Fprintf(os.Stderr, "  %s: %s (%s from %s)\n", word.Letter, word.Lemma, word.PartOfSpeech, word.Source)
+		}
+	}
+}
+
+// defaultIndexCache returns the user's cache directory for mnemonic, or nothing if they don't have one
+func defaultIndexCache() string {
+	if cacheHome := os.Getenv("XDG_CACHE_HOME"); cacheHome != "" {
+		return filepath.Join(cacheHome, "mnemonic")
+	}
+
+	if home := os.Getenv("HOME"); home != "" {
+		return filepath.Join(home, ".cache", "mnemonic")
+	}
+
+	return ""
+}
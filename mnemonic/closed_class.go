@@ -0,0 +1,115 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"strings"
+)
+
+// closedClassSource is the source given to words from the closed-class lexicon
+const closedClassSource = "closed-class"
+
+// closedClassWords are the function words WordNet leaves out, one part of speech and word per line separated by a
+// tab, like the embedded lexicon's word list
+var closedClassWords = strings.Join([]string{
+	closedClassLines("prep", "about above across after against along amid among around at before behind below "+
+		"beneath beside between beyond by despite down during except for from in inside into like near of off on "+
+		"onto opposite out outside over past per round since through throughout till to toward towards under "+
+		"underneath unlike until up upon via with within without"),
+	closedClassLines("det", "a all an another any both each either enough every few her his its many more most "+
+		"much my neither no our several some such that the their these this those what which whose your"),
+	closedClassLines("pron", "anybody anyone anything everybody everyone everything he her hers herself him "+
+		"himself his it its itself me mine myself nobody none nothing one ours ourselves she somebody someone "+
+		"something that them themselves they this those us we what which who whom whose you yours yourself"),
+	closedClassLines("conj", "after although and as because before but either if lest neither nor once or since "+
+		"so than that though till unless until when whenever where whereas wherever whether while yet"),
+}, "\n")
+
+// closedClassLines returns the words as lines of the part of speech and the word
+func closedClassLines(partOfSpeech string, words string) string {
+	lines := []string{}
+
+	for _, word := range strings.Fields(words) {
+		lines = append(lines, partOfSpeech+"\t"+word)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ClosedClassLexicon returns the function words that are built into the program
+//
+// They're prepositions (prep), determiners (det), pronouns (pron) and conjunctions (conj), the small words WordNet
+// doesn't have that classic mnemonics like "Richard Of York Gave Battle In Vain" lean on. Generate adds them to
+// any dictionary that doesn't have words for those parts of speech of its own, unless it's kept to domains.
+//
+// Could be used like
+//   generator := mnemonic.NewLexiconWordGenerator(mnemonic.ClosedClassLexicon(), "prep", random)
+func ClosedClassLexicon() *Lexicon {
+	entries, err := readTaggedWords(strings.NewReader(closedClassWords), closedClassSource)
+
+	if err != nil {
+		panic(err)
+	}
+
+	lexicon := NewLexicon(entries...)
+	lexicon.sortPools()
+
+	return lexicon
+}
+
+// NewClosedClassWordGenerators returns a word generator for each of the closed-class parts of speech
+//
+// Could be used like
+//   generators := append(myGenerators, mnemonic.NewClosedClassWordGenerators(mnemonic.NewSeededRandomSource(42))...)
+func NewClosedClassWordGenerators(random RandomSource) []WordGeneratorV2 {
+	return NewLexiconWordGenerators(ClosedClassLexicon(), random)
+}
+
+// withPartsOfSpeech returns a lexicon with this lexicon's words, and the other lexicon's words for the parts of
+// speech this one has no words for
+//
+// The pools are shared rather than copied, so it's quick however big the lexicons are, and it mustn't be added to.
+func (l *Lexicon) withPartsOfSpeech(other *Lexicon) *Lexicon {
+	combined := &Lexicon{
+		pools:       make(map[string]map[string][]LexiconEntry),
+		cumulative:  make(map[string]map[string][]int),
+		weighted:    l.weighted,
+		frequencies: l.frequencies,
+		size:        l.size,
+	}
+
+	for partOfSpeech := range l.pools {
+		combined.pools[partOfSpeech] = l.pools[partOfSpeech]
+		combined.cumulative[partOfSpeech] = l.cumulative[partOfSpeech]
+	}
+
+	for partOfSpeech, letters := range other.pools {
+		if combined.pools[partOfSpeech] != nil {
+			continue
+		}
+
+		combined.pools[partOfSpeech] = letters
+		combined.cumulative[partOfSpeech] = other.cumulative[partOfSpeech]
+		combined.weighted = combined.weighted || other.weighted
+		combined.frequencies = combined.frequencies || other.frequencies
+
+		for _, entries := range letters {
+			combined.size += len(entries)
+		}
+	}
+
+	return combined
+}
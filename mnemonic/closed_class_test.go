@@ -0,0 +1,140 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("ClosedClassLexicon", func() {
+	It("Has prepositions, determiners, pronouns and conjunctions", func() {
+		lexicon := ClosedClassLexicon()
+
+		Expect(lexicon.PartsOfSpeech()).To(Equal([]string{"conj", "det", "prep", "pron"}))
+		Expect(lemmas(lexicon.Pool("prep", "o"))).To(ContainElement("of"))
+		Expect(lemmas(lexicon.Pool("det", "t"))).To(ContainElement("the"))
+		Expect(lemmas(lexicon.Pool("pron", "s"))).To(ContainElement("she"))
+		Expect(lemmas(lexicon.Pool("conj", "a"))).To(ContainElement("and"))
+		Expect(lexicon.Pool("prep", "o")[0].Source).To(Equal("closed-class"))
+	})
+	It("Has a word generator for each part of speech", func() {
+		names := []string{}
+
+		for _, generator := range NewClosedClassWordGenerators(NewSeededRandomSource(42)) {
+			names = append(names, generator.GetFuncName())
+		}
+
+		Expect(names).To(Equal([]string{"conj", "det", "prep", "pron"}))
+	})
+	It("Lets grammars use function words for letters", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun prep det noun .`))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "river", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "york", PartOfSpeech: "noun"},
+		)
+
+		actual, err := Generate(context.Background(), "roty", WithLexicon(lexicon), WithGrammar(grammar), WithSeed(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Words[1].PartOfSpeech).To(Equal("prep"))
+		Expect(actual.Words[1].Source).To(Equal("closed-class"))
+		Expect(actual.Words[2].PartOfSpeech).To(Equal("det"))
+	})
+	It("Uses the dictionary's own function words when it has them", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun prep noun .`))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "river", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "onward", PartOfSpeech: "prep"},
+			LexiconEntry{Lemma: "york", PartOfSpeech: "noun"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(context.Background(), "roy", WithLexicon(lexicon), WithGrammar(grammar), WithSeed(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("river onward york."))
+		}
+	})
+	It("Leaves function words out when keeping to domains", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "hen", PartOfSpeech: "noun", Domain: "noun.animal"},
+			LexiconEntry{Lemma: "egg", PartOfSpeech: "noun", Domain: "noun.food"},
+			LexiconEntry{Lemma: "lamb", PartOfSpeech: "noun", Domain: "noun.animal"},
+			LexiconEntry{Lemma: "olive", PartOfSpeech: "noun", Domain: "noun.food"},
+			LexiconEntry{Lemma: "hop", PartOfSpeech: "verb", Domain: "verb.motion"},
+		)
+
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"hello",
+				WithLexicon(lexicon),
+				WithDomains("animal", "food"),
+				WithTemplateStyle(StyleProse),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, word := range actual.Words {
+				Expect(word.PartOfSpeech).To(Equal("noun"))
+			}
+		}
+	})
+	It("Only uses function words in prose when there are word generators for them", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"thequickbrownfox",
+				WithWordGenerators(testGenerators()...),
+				WithTemplateStyle(StyleProse),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, word := range actual.Words {
+				Expect(word.PartOfSpeech).To(BeElementOf("adj", "noun", "verb", "adv"))
+			}
+		}
+	})
+})
+
+// lemmas returns the words of the entries
+func lemmas(entries []LexiconEntry) []string {
+	words := []string{}
+
+	for _, entry := range entries {
+		words = append(words, entry.Lemma)
+	}
+
+	return words
+}
+
+func ExampleClosedClassLexicon() {
+	entry, _ := ClosedClassLexicon().Pick("prep", "o", NewSeededRandomSource(42))
+
+	fmt.Println(entry.PartOfSpeech, strings.HasPrefix(entry.Lemma, "o"))
+	// Output: prep true
+}
@@ -0,0 +1,207 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"os"
+	"strings"
+)
+
+// usageTagPrefix starts the tags that say what register a word is in, like usage:slang
+const usageTagPrefix = "usage:"
+
+// SafeUsages are the usage domains that safe mode leaves out
+var SafeUsages = []string{
+	"vulgarism",
+	"obscenity",
+	"profanity",
+	"slang",
+	"ethnic slur",
+	"disparagement",
+	"derogation",
+	"offensive",
+}
+
+// defaultBlocklist are the slurs and obscenities safe mode leaves out whatever the dictionary says about them
+//
+// Words with an everyday sense, like cock for a rooster, aren't here. Their rude senses are left out by the
+// dictionary's usage domains instead.
+var defaultBlocklist = []string{
+	"arse", "arsehole", "asshole", "bastard", "bollocks", "bugger", "bullshit", "clit", "cum", "cunt", "dick",
+	"dildo", "fag", "faggot", "fuck", "fucker", "fucking", "goddamn", "jizz", "nigger", "piss", "pussy", "retard",
+	"shit", "slut", "tits", "turd", "twat", "wank", "wanker", "whore",
+}
+
+// UsageTag returns the tag for words in a usage domain, like slang
+//
+// Could be used like
+//   mnemonic.LexiconEntry{Lemma: "gnarly", PartOfSpeech: "adj", Tags: []string{mnemonic.UsageTag("slang")}}
+func UsageTag(usage string) string {
+	return usageTagPrefix + strings.ToLower(strings.Replace(usage, "_", " ", -1))
+}
+
+// ContentFilter leaves out words that are blocked, or tagged with a blocked tag, unless they're allowed
+type ContentFilter struct {
+	blocked     map[string]bool
+	allowed     map[string]bool
+	blockedTags map[string]bool
+}
+
+// NewContentFilter returns a filter that doesn't leave anything out until it's told to
+//
+// Could be used like
+//   filter := mnemonic.NewContentFilter()
+//   filter.Block("moist")
+func NewContentFilter() *ContentFilter {
+	return &ContentFilter{
+		blocked:     make(map[string]bool),
+		allowed:     make(map[string]bool),
+		blockedTags: make(map[string]bool),
+	}
+}
+
+// NewSafeContentFilter returns a filter that leaves out vulgar, offensive and slang words
+//
+// It blocks the SafeUsages and a built in list of words. Allow words to let them back in.
+//
+// Could be used like
+//   filter := mnemonic.NewSafeContentFilter()
+func NewSafeContentFilter() *ContentFilter {
+	filter := NewContentFilter()
+	filter.Block(defaultBlocklist...)
+
+	for _, usage := range SafeUsages {
+		filter.BlockTags(UsageTag(usage))
+	}
+
+	return filter
+}
+
+// Block leaves out the words, and phrases that have them as one of their words
+func (f *ContentFilter) Block(words ...string) {
+	for _, word := range words {
+		f.blocked[contentKey(word)] = true
+	}
+}
+
+// Allow lets the words in, even when they're blocked or have a blocked tag
+func (f *ContentFilter) Allow(words ...string) {
+	for _, word := range words {
+		f.allowed[contentKey(word)] = true
+	}
+}
+
+// BlockTags leaves out words with any of the tags
+func (f *ContentFilter) BlockTags(tags ...string) {
+	for _, tag := range tags {
+		f.blockedTags[tag] = true
+	}
+}
+
+// Keep returns true if the word isn't blocked, it can be used as a WordFilter
+//
+// Could be used like
+//   mnemonic.NewFilteredWordGenerator(generator, filter.Keep)
+func (f *ContentFilter) Keep(word Word) bool {
+	return f.keep(word.Lemma, word.Tags)
+}
+
+// Apply returns a new lexicon without the blocked words
+//
+// Every sense of a blocked word is left out, but a blocked tag only leaves out the senses that have it. So bread is
+// kept for the food even though its slang sense is money.
+//
+// Could be used like
+//   lexicon = mnemonic.NewSafeContentFilter().Apply(lexicon)
+func (f *ContentFilter) Apply(lexicon *Lexicon) *Lexicon {
+	kept := []LexiconEntry{}
+
+	for _, entry := range lexicon.Entries() {
+		if f.keep(entry.Lemma, entry.Tags) {
+			kept = append(kept, entry)
+		}
+	}
+
+	return NewLexicon(kept...)
+}
+
+// keep returns true if a word with the tags isn't blocked
+func (f *ContentFilter) keep(lemma string, tags []string) bool {
+	key := contentKey(lemma)
+
+	if f.allowed[key] {
+		return true
+	}
+
+	for _, tag := range tags {
+		if f.blockedTags[tag] {
+			return false
+		}
+	}
+
+	if f.blocked[key] {
+		return false
+	}
+
+	for _, part := range strings.FieldsFunc(key, isWordSeparator) {
+		if f.blocked[part] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// LoadWordSet reads a file of words, one per line, for blocking or allowing
+//
+// Blank lines and lines starting with # are skipped.
+//
+// Could be used like
+//   words, err := mnemonic.LoadWordSet("/tmp/blocklist.txt")
+//   filter.Block(words...)
+func LoadWordSet(path string) ([]string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	entries, err := ReadWordList(file, "", path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	words := []string{}
+
+	for _, entry := range entries {
+		words = append(words, entry.Lemma)
+	}
+
+	return words, nil
+}
+
+// contentKey returns what the filter matches words on, ignoring case and how the words in a phrase are joined
+func contentKey(word string) string {
+	return strings.ToLower(strings.Replace(word, "_", " ", -1))
+}
+
+// isWordSeparator returns true for the characters between the words of a phrase
+func isWordSeparator(r rune) bool {
+	return r == ' ' || r == '-'
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("ContentFilter", func() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "gnarly", PartOfSpeech: "adj", Tags: []string{UsageTag("slang")}},
+		LexiconEntry{Lemma: "gnarly", PartOfSpeech: "adj", Gloss: "full of knots"},
+		LexiconEntry{Lemma: "gentle", PartOfSpeech: "adj"},
+		LexiconEntry{Lemma: "shit", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "horse_shit", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "shiitake", PartOfSpeech: "noun"},
+	)
+
+	It("Leaves nothing out until told to", func() {
+		Expect(NewContentFilter().Apply(lexicon).Len()).To(Equal(6))
+	})
+	It("Leaves out the senses in unsafe usages, and blocked words in phrases", func() {
+		actual := NewSafeContentFilter().Apply(lexicon)
+
+		Expect(actual.Entries()).To(Equal([]LexiconEntry{
+			{Lemma: "gnarly", PartOfSpeech: "adj", Gloss: "full of knots"},
+			{Lemma: "gentle", PartOfSpeech: "adj"},
+			{Lemma: "shiitake", PartOfSpeech: "noun"},
+		}))
+	})
+	It("Keeps the everyday sense of a word with a slang sense", func() {
+		slang := NewLexicon(
+			LexiconEntry{Lemma: "bread", PartOfSpeech: "noun", Gloss: "food made from dough"},
+			LexiconEntry{Lemma: "bread", PartOfSpeech: "noun", Gloss: "money", Tags: []string{UsageTag("slang")}},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"b",
+				WithLexicon(slang),
+				WithContentFilter(NewSafeContentFilter()),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Words[0].Gloss).To(Equal("food made from dough"))
+		}
+	})
+	It("Leaves everyday words with a rude sense to the usage domains", func() {
+		birds := NewLexicon(
+			LexiconEntry{Lemma: "cock", PartOfSpeech: "noun", Gloss: "adult male chicken"},
+			LexiconEntry{Lemma: "cock", PartOfSpeech: "noun", Tags: []string{UsageTag("obscenity")}},
+			LexiconEntry{Lemma: "tit", PartOfSpeech: "noun", Gloss: "small active songbird"},
+		)
+
+		actual := NewSafeContentFilter().Apply(birds)
+
+		Expect(actual.Entries()).To(Equal([]LexiconEntry{
+			{Lemma: "cock", PartOfSpeech: "noun", Gloss: "adult male chicken"},
+			{Lemma: "tit", PartOfSpeech: "noun", Gloss: "small active songbird"},
+		}))
+	})
+	It("Lets allowed words back in", func() {
+		filter := NewSafeContentFilter()
+		filter.Allow("Gnarly")
+
+		Expect(filter.Apply(lexicon).Pool("adj", "g")).To(HaveLen(3))
+	})
+	It("Blocks the user's words too", func() {
+		filter := NewContentFilter()
+		filter.Block("gentle")
+
+		Expect(filter.Keep(Word{Lemma: "Gentle"})).To(BeFalse())
+		Expect(filter.Keep(Word{Lemma: "gnarly"})).To(BeTrue())
+	})
+	It("Filters words from generators", func() {
+		filter := NewContentFilter()
+		filter.Block("apple")
+
+		_, err := Generate(
+			context.Background(),
+			"a",
+			WithWordGenerators(NewWordGeneratorAdapter(NewStaticWordGenerator("apple", "noun"))),
+			WithContentFilter(filter),
+		)
+
+		Expect(err).To(BeAssignableToTypeOf(&NoWordError{}))
+	})
+	It("Reads word sets from files", func() {
+		dir, err := ioutil.TempDir("", "mnemonic-blocklist")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "blocklist.txt")
+		Expect(ioutil.WriteFile(path, []byte("# ours\nmoist\n\nsynergy\n"), 0644)).To(Succeed())
+
+		actual, err := LoadWordSet(path)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal([]string{"moist", "synergy"}))
+	})
+})
+
+var _ = Describe("LoadWordNetUsages", func() {
+	It("Tags the synsets in a usage domain with every word of the domain", func() {
+		dir, err := ioutil.TempDir("", "mnemonic-dict")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		files := map[string]string{
+			"data.noun": "  1 This software and database is being provided\n" +
+				"07155661 10 n 02 vulgarism 0 obscenity 0 001 -u 02000001 v 0000 | an offensive word\n" +
+				"07157123 10 n 01 slang 0 000 | informal language\n",
+			"data.verb": "02000001 30 v 01 screw_up 0 002 ;u 07155661 n 0000 ;u 07157123 n 0000 01 + 08 00 | spoil\n" +
+				"02000002 30 v 01 screw_up 0 000 01 + 08 00 | make a mess of, in a mild way\n",
+			"data.adj": "00001740 00 a 01 able(a) 0 000 | having the necessary means\n",
+			"data.adv": "",
+		}
+
+		for name, contents := range files {
+			Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)).To(Succeed())
+		}
+
+		actual, err := LoadWordNetUsages(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(map[string][]string{
+			"02000001-verb": {UsageTag("obscenity"), UsageTag("slang"), UsageTag("vulgarism")},
+		}))
+	})
+	It("Errors when the dictionary isn't there", func() {
+		_, err := LoadWordNetUsages("/does/not/exist")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,133 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"fmt"
+	"strings"
+)
+
+// DomainError is returned when the domains leave no words for a part of speech they restrict
+type DomainError struct {
+	Domains      []string
+	PartOfSpeech string
+}
+
+// Error describes which domains left nothing behind
+func (e *DomainError) Error() string {
+	if e.PartOfSpeech == "" {
+		return fmt.Sprintf("no words in the domains %s", strings.Join(e.Domains, ", "))
+	}
+
+	return fmt.Sprintf(
+		"no %s in the domains %s, the dictionary might not have domains",
+		e.PartOfSpeech,
+		strings.Join(e.Domains, ", "),
+	)
+}
+
+// WithDomains returns a lexicon that only has words in the domains, like noun.animal or noun.food
+//
+// A domain starting with a part of speech, like WordNet's lexicographer files, only restricts that part of speech,
+// so noun.animal leaves the verbs alone. Any other domain, like animal, restricts every part of speech, and matches
+// the lexicographer file after the dot as well as words tagged with it. With no domains the lexicon is unchanged.
+//
+// A DomainError is returned when a part of speech the domains restrict has no words left, or with only domains like
+// animal, when no words are left at all. Word lists without domains lose every word of a part of speech this way.
+//
+// Could be used like
+//   animals, err := lexicon.WithDomains("noun.animal", "noun.food")
+func (l *Lexicon) WithDomains(domains ...string) (*Lexicon, error) {
+	if len(domains) == 0 {
+		return l, nil
+	}
+
+	kept := []LexiconEntry{}
+
+	for _, entry := range l.Entries() {
+		if inDomains(entry, domains) {
+			kept = append(kept, entry)
+		}
+	}
+
+	restricted := NewLexicon(kept...)
+
+	if len(kept) == 0 {
+		return nil, &DomainError{Domains: domains}
+	}
+
+	for _, partOfSpeech := range l.PartsOfSpeech() {
+		if domainsRestrict(domains, partOfSpeech) && restricted.pools[partOfSpeech] == nil {
+			return nil, &DomainError{Domains: domains, PartOfSpeech: partOfSpeech}
+		}
+	}
+
+	return restricted, nil
+}
+
+// domainsRestrict returns true if one of the domains starts with the part of speech
+func domainsRestrict(domains []string, partOfSpeech string) bool {
+	for _, domain := range domains {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(domain)), partOfSpeech+".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// inDomains returns true if the entry is in one of the domains, or none of them are for its part of speech
+func inDomains(entry LexiconEntry, domains []string) bool {
+	restricted := false
+
+	for _, domain := range domains {
+		domain = strings.ToLower(strings.TrimSpace(domain))
+		partOfSpeech := ""
+
+		if dot := strings.Index(domain, "."); dot >= 0 {
+			partOfSpeech = domain[:dot]
+		}
+
+		if partOfSpeech != "" && partOfSpeech != entry.PartOfSpeech {
+			continue
+		}
+
+		restricted = true
+
+		if inDomain(entry, domain) {
+			return true
+		}
+	}
+
+	return !restricted
+}
+
+// inDomain returns true if the entry's domain or one of its tags is the domain
+func inDomain(entry LexiconEntry, domain string) bool {
+	entryDomain := strings.ToLower(entry.Domain)
+
+	if entryDomain == domain || strings.HasSuffix(entryDomain, "."+domain) {
+		return true
+	}
+
+	for _, tag := range entry.Tags {
+		if strings.ToLower(tag) == domain {
+			return true
+		}
+	}
+
+	return false
+}
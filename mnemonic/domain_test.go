@@ -0,0 +1,95 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Lexicon.WithDomains", func() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "aardvark", PartOfSpeech: "noun", Domain: "noun.animal"},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Domain: "noun.food"},
+		LexiconEntry{Lemma: "anvil", PartOfSpeech: "noun", Domain: "noun.artifact"},
+		LexiconEntry{Lemma: "amble", PartOfSpeech: "verb", Domain: "verb.motion"},
+		LexiconEntry{Lemma: "acorn", PartOfSpeech: "noun", Tags: []string{"food"}},
+	)
+
+	It("Keeps the words in the domains, leaving other parts of speech alone", func() {
+		actual, err := lexicon.WithDomains("noun.animal", "Noun.Food")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{Lemma: "aardvark", PartOfSpeech: "noun", Domain: "noun.animal"},
+			{Lemma: "apple", PartOfSpeech: "noun", Domain: "noun.food"},
+		}))
+		Expect(actual.Pool("verb", "a")).To(HaveLen(1))
+	})
+	It("Matches domains without a part of speech against every word and its tags", func() {
+		actual, err := lexicon.WithDomains("food")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{Lemma: "apple", PartOfSpeech: "noun", Domain: "noun.food"},
+			{Lemma: "acorn", PartOfSpeech: "noun", Tags: []string{"food"}},
+		}))
+		Expect(actual.Pool("verb", "a")).To(BeEmpty())
+	})
+	It("Returns the lexicon unchanged without domains", func() {
+		actual, err := lexicon.WithDomains()
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(BeIdenticalTo(lexicon))
+	})
+	It("Errors when a domain leaves its part of speech without words", func() {
+		_, err := lexicon.WithDomains("noun.animal", "verb.contact")
+
+		Expect(err).To(Equal(&DomainError{Domains: []string{"noun.animal", "verb.contact"}, PartOfSpeech: "verb"}))
+	})
+	It("Errors when the domains leave no words at all", func() {
+		_, err := lexicon.WithDomains("weather")
+
+		Expect(err).To(Equal(&DomainError{Domains: []string{"weather"}}))
+	})
+	It("Errors rather than losing every word of a list without domains", func() {
+		undomained := NewLexicon(
+			LexiconEntry{Lemma: "aardvark", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+		)
+
+		_, err := undomained.WithDomains("noun.animal")
+
+		Expect(err).To(BeAssignableToTypeOf(&DomainError{}))
+	})
+})
+
+func ExampleLexicon_WithDomains() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "badger", PartOfSpeech: "noun", Domain: "noun.animal"},
+		LexiconEntry{Lemma: "bucket", PartOfSpeech: "noun", Domain: "noun.artifact"},
+	)
+
+	animals, _ := lexicon.WithDomains("noun.animal")
+
+	for _, entry := range animals.Entries() {
+		fmt.Println(entry.Lemma)
+	}
+	// Output: badger
+}
@@ -0,0 +1,88 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+)
+
+// Escaping is how a mnemonic is made safe for wherever it's going to be put
+type Escaping int
+
+const (
+	// EscapePlain leaves the mnemonic as it is, for reading in a terminal
+	EscapePlain Escaping = iota
+	// EscapeHTML escapes the mnemonic so it can be put in HTML
+	EscapeHTML
+	// EscapeShell quotes the mnemonic so it's a single shell argument
+	EscapeShell
+	// EscapeJSON turns the mnemonic into a JSON string
+	EscapeJSON
+)
+
+// escapingNames are the names of the escaping modes, in the same order as the constants
+var escapingNames = []string{"plain", "html", "shell", "json"}
+
+// ParseEscaping returns the escaping mode with the given name
+//
+// Might be used like this
+//   escaping, err := mnemonic.ParseEscaping("html")
+func ParseEscaping(name string) (Escaping, error) {
+	for i := range escapingNames {
+		if escapingNames[i] == name {
+			return Escaping(i), nil
+		}
+	}
+
+	return EscapePlain, fmt.Errorf(
+		"unknown escaping %q, expected one of %s",
+		name,
+		strings.Join(escapingNames, ", "),
+	)
+}
+
+// String returns the name of the escaping mode
+func (e Escaping) String() string {
+	if int(e) < 0 || int(e) >= len(escapingNames) {
+		return fmt.Sprintf("Escaping(%d)", int(e))
+	}
+
+	return escapingNames[e]
+}
+
+// Escape returns the text escaped for this mode
+func (e Escaping) Escape(text string) string {
+	switch e {
+	case EscapeHTML:
+		return html.EscapeString(text)
+	case EscapeShell:
+		return "'" + strings.Replace(text, "'", `'\''`, -1) + "'"
+	case EscapeJSON:
+		buffer := &bytes.Buffer{}
+		encoder := json.NewEncoder(buffer)
+		encoder.SetEscapeHTML(false)
+		// Encoding a string can't fail
+		_ = encoder.Encode(text)
+
+		return strings.TrimSuffix(buffer.String(), "\n")
+	default:
+		return text
+	}
+}
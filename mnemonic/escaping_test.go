@@ -0,0 +1,61 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+
+	"fmt"
+)
+
+var _ = Describe("Escaping", func() {
+	Context("Escape", func() {
+		It("Leaves plain text alone", func() {
+			Expect(EscapePlain.Escape("jack-o'-lantern & <b>")).To(Equal("jack-o'-lantern & <b>"))
+		})
+		It("Escapes HTML", func() {
+			Expect(EscapeHTML.Escape("jack-o'-lantern & <b>")).To(Equal("jack-o&#39;-lantern &amp; &lt;b&gt;"))
+		})
+		It("Quotes for the shell", func() {
+			Expect(EscapeShell.Escape("jack-o'-lantern $HOME")).To(Equal(`'jack-o'\''-lantern $HOME'`))
+		})
+		It("Makes a JSON string", func() {
+			Expect(EscapeJSON.Escape("say \"hi\" & <b>")).To(Equal(`"say \"hi\" & <b>"`))
+		})
+	})
+	Context("Parse", func() {
+		It("Finds each mode by name", func() {
+			for _, expected := range []Escaping{EscapePlain, EscapeHTML, EscapeShell, EscapeJSON} {
+				actual, err := ParseEscaping(expected.String())
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual).To(Equal(expected))
+			}
+		})
+		It("Errors on unknown modes", func() {
+			_, err := ParseEscaping("xml")
+
+			Expect(err).To(MatchError(`unknown escaping "xml", expected one of plain, html, shell, json`))
+		})
+	})
+})
+
+func ExampleEscaping_Escape() {
+	fmt.Println(EscapeShell.Escape("jack-o'-lantern"))
+	// Output: 'jack-o'\''-lantern'
+}
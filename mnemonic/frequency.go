@@ -0,0 +1,277 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// cntlistFile is the file in a WordNet dictionary with how often each sense was seen in tagged text
+const cntlistFile = "cntlist.rev"
+
+// senseKeyPartsOfSpeech maps the synset type in a WordNet sense key to the names templates use
+var senseKeyPartsOfSpeech = map[string]string{
+	"1": "noun",
+	"2": "verb",
+	"3": "adj",
+	"4": "adv",
+	"5": "adj",
+}
+
+// Frequencies is how often words are used, keyed by the word and its part of speech
+//
+// Lists that don't know the part of speech give the same frequency for every part of speech.
+type Frequencies map[string]int
+
+// LoadCntlist loads the sense tag counts from a WordNet cntlist or cntlist.rev file
+//
+// Could be used like
+//   frequencies, err := mnemonic.LoadCntlist("/tmp/dict/cntlist.rev")
+func LoadCntlist(path string) (Frequencies, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return ReadCntlist(file)
+}
+
+// ReadCntlist reads WordNet sense tag counts, adding up the senses of each word
+//
+// Both cntlist, which has the count first, and cntlist.rev, which has the sense key first, can be read.
+//
+// Could be used like
+//   frequencies, err := mnemonic.ReadCntlist(strings.NewReader("apple%1:13:00:: 1 5\n"))
+func ReadCntlist(reader io.Reader) (Frequencies, error) {
+	frequencies := make(Frequencies)
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+
+		if len(fields) == 0 {
+			continue
+		}
+
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("line %d: expected a sense key, sense number and count", line)
+		}
+
+		senseKey, count := fields[0], fields[2]
+
+		if !strings.Contains(senseKey, "%") {
+			senseKey, count = fields[1], fields[0]
+		}
+
+		lemma, partOfSpeech, err := parseSenseKey(senseKey)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %v", line, err)
+		}
+
+		tagged, err := strconv.Atoi(count)
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: count %q isn't a number", line, count)
+		}
+
+		frequencies[frequencyKey(lemma, partOfSpeech)] += tagged
+	}
+
+	return frequencies, scanner.Err()
+}
+
+// parseSenseKey returns the word and part of speech in a WordNet sense key, like apple%1:13:00::
+func parseSenseKey(senseKey string) (string, string, error) {
+	parts := strings.SplitN(senseKey, "%", 2)
+
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("%q isn't a sense key", senseKey)
+	}
+
+	partOfSpeech, ok := senseKeyPartsOfSpeech[parts[1][:1]]
+
+	if !ok {
+		return "", "", fmt.Errorf("%q has an unknown synset type", senseKey)
+	}
+
+	return parts[0], partOfSpeech, nil
+}
+
+// LoadFrequencyList loads a list of words and how often they're used
+//
+// See ReadFrequencyList for the format.
+//
+// Could be used like
+//   frequencies, err := mnemonic.LoadFrequencyList("/tmp/count_1w.txt")
+func LoadFrequencyList(path string) (Frequencies, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	frequencies, err := ReadFrequencyList(file)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return frequencies, nil
+}
+
+// ReadFrequencyList reads a word and a count on each line, separated by spaces, a tab or a comma
+//
+// Lists with only words are taken to be ranked, most common first. Blank lines and lines starting with # are
+// skipped.
+//
+// Could be used like
+//   frequencies, err := mnemonic.ReadFrequencyList(strings.NewReader("the 23135851162\nof 13151942776\n"))
+func ReadFrequencyList(reader io.Reader) (Frequencies, error) {
+	frequencies := make(Frequencies)
+	ranked := []string{}
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+
+		if len(fields) == 1 {
+			ranked = append(ranked, fields[0])
+			continue
+		}
+
+		count, err := strconv.Atoi(fields[len(fields)-1])
+
+		if err != nil || count < 0 {
+			return nil, fmt.Errorf("line %d: count %q isn't a whole number", line, fields[len(fields)-1])
+		}
+
+		frequencies[frequencyKey(strings.Join(fields[:len(fields)-1], " "), "")] += count
+	}
+
+	for rank, word := range ranked {
+		key := frequencyKey(word, "")
+
+		if _, ok := frequencies[key]; !ok {
+			frequencies[key] = len(ranked) - rank
+		}
+	}
+
+	return frequencies, scanner.Err()
+}
+
+// Frequency returns how often a word is used as a part of speech, or 0 if we don't know
+func (f Frequencies) Frequency(lemma string, partOfSpeech string) int {
+	if frequency, ok := f[frequencyKey(lemma, partOfSpeech)]; ok {
+		return frequency
+	}
+
+	return f[frequencyKey(lemma, "")]
+}
+
+// Apply returns a new lexicon with the frequencies set on the words they know about
+//
+// Could be used like
+//   lexicon = frequencies.Apply(lexicon)
+func (f Frequencies) Apply(lexicon *Lexicon) *Lexicon {
+	entries := lexicon.Entries()
+
+	for i := range entries {
+		if frequency := f.Frequency(entries[i].Lemma, entries[i].PartOfSpeech); frequency > 0 {
+			entries[i].Frequency = frequency
+		}
+	}
+
+	return NewLexicon(entries...)
+}
+
+// WithCommonness returns a new lexicon weighted towards the words that are used most
+//
+// Each word's weight is multiplied by its frequency plus one, over that of the most used word for its letter, to the
+// power of commonness. So 0 ignores frequency and bigger numbers favour everyday words more, and the biggest counts
+// can't overflow the weights. A word's frequency is shared between its senses, so a word with many senses isn't
+// picked more than one with a single sense that's as common. When the lexicon knows any frequencies, words used less
+// than minFrequency times are left out. A lexicon with no frequencies is returned as it is.
+//
+// Could be used like
+//   lexicon = lexicon.WithCommonness(1, 2)
+func (l *Lexicon) WithCommonness(commonness float64, minFrequency int) *Lexicon {
+	if !l.frequencies || (commonness == 0 && minFrequency <= 0) {
+		return l
+	}
+
+	entries := []LexiconEntry{}
+
+	for _, partOfSpeech := range l.PartsOfSpeech() {
+		for _, pool := range l.pools[partOfSpeech] {
+			mostUsed := 0
+			senses := make(map[string]int)
+
+			for _, entry := range pool {
+				senses[entry.Lemma]++
+
+				if entry.Frequency > mostUsed {
+					mostUsed = entry.Frequency
+				}
+			}
+
+			for _, entry := range pool {
+				if entry.Frequency < minFrequency {
+					continue
+				}
+
+				if commonness != 0 {
+					share := math.Pow(float64(entry.Frequency+1)/float64(mostUsed+1), commonness)
+					entry.Weight = entry.effectiveWeight() * share / float64(senses[entry.Lemma])
+				}
+
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return NewLexicon(entries...)
+}
+
+// frequencyKey returns what frequencies are matched on, the word ignoring case and its part of speech
+//
+// WordNet joins the words in a phrase with underscores where other lists use spaces, so they're treated the same.
+func frequencyKey(lemma string, partOfSpeech string) string {
+	return wordKey(strings.Replace(lemma, "_", " ", -1), partOfSpeech)
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Frequencies", func() {
+	Context("cntlist", func() {
+		It("Adds up the senses of each word from cntlist.rev", func() {
+			actual, err := ReadCntlist(strings.NewReader(
+				"apple%1:13:00:: 1 5\napple%1:20:00:: 2 1\nable%5:00:00:capable:00 1 40\nice_cream%1:13:00:: 1 9\n",
+			))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Frequency("apple", "noun")).To(Equal(6))
+			Expect(actual.Frequency("apple", "verb")).To(Equal(0))
+			Expect(actual.Frequency("able", "adj")).To(Equal(40))
+			Expect(actual.Frequency("ice cream", "noun")).To(Equal(9))
+		})
+		It("Reads cntlist with the count first", func() {
+			actual, err := ReadCntlist(strings.NewReader("1257 be%2:42:03:: 1\n"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Frequency("be", "verb")).To(Equal(1257))
+		})
+		It("Says which line is wrong", func() {
+			_, err := ReadCntlist(strings.NewReader("apple%1:13:00:: 1 5\napple 1 5\n"))
+
+			Expect(err).To(MatchError(`line 2: "1" isn't a sense key`))
+		})
+	})
+	Context("Frequency lists", func() {
+		It("Reads words and counts for every part of speech", func() {
+			actual, err := ReadFrequencyList(strings.NewReader("# counts\nthe 2313\nApple,12\nice cream\t4\n"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Frequency("apple", "noun")).To(Equal(12))
+			Expect(actual.Frequency("apple", "verb")).To(Equal(12))
+			Expect(actual.Frequency("ice_cream", "noun")).To(Equal(4))
+		})
+		It("Ranks lists with only words, most common first", func() {
+			actual, err := ReadFrequencyList(strings.NewReader("the\nof\napple\n"))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Frequency("the", "")).To(Equal(3))
+			Expect(actual.Frequency("apple", "")).To(Equal(1))
+		})
+	})
+	Context("Commonness", func() {
+		var lexicon *Lexicon
+
+		BeforeEach(func() {
+			frequencies, _ := ReadFrequencyList(strings.NewReader("apple 99\nacorn 1\n"))
+			lexicon = frequencies.Apply(NewLexicon(
+				LexiconEntry{Lemma: "acorn", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "azimuth", PartOfSpeech: "noun"},
+			))
+		})
+
+		It("Weights words by how common they are", func() {
+			actual := lexicon.WithCommonness(1, 0)
+
+			Expect(actual.Pool("noun", "a")).To(Equal([]LexiconEntry{
+				{Lemma: "acorn", PartOfSpeech: "noun", Frequency: 1, Weight: 0.02},
+				{Lemma: "apple", PartOfSpeech: "noun", Frequency: 99, Weight: 1},
+				{Lemma: "azimuth", PartOfSpeech: "noun", Weight: 0.01},
+			}))
+		})
+		It("Still favours the most common words when the counts are huge", func() {
+			frequencies, err := ReadFrequencyList(strings.NewReader("the 23135851162\nthimble 1000\n"))
+			Expect(err).NotTo(HaveOccurred())
+			huge := frequencies.Apply(NewLexicon(
+				LexiconEntry{Lemma: "the", PartOfSpeech: "adj"},
+				LexiconEntry{Lemma: "thimble", PartOfSpeech: "adj"},
+			)).WithCommonness(2, 0)
+
+			picked := 0
+
+			for seed := int64(0); seed < 100; seed++ {
+				if entry, _ := huge.Pick("adj", "t", NewSeededRandomSource(seed)); entry.Lemma == "the" {
+					picked++
+				}
+			}
+
+			Expect(picked).To(BeNumerically(">", 90))
+		})
+		It("Shares a word's frequency between its senses", func() {
+			frequencies, err := ReadFrequencyList(strings.NewReader("bank 9\nbeach 9\n"))
+			Expect(err).NotTo(HaveOccurred())
+			senses := frequencies.Apply(NewLexicon(
+				LexiconEntry{Lemma: "bank", PartOfSpeech: "noun", Gloss: "river side"},
+				LexiconEntry{Lemma: "bank", PartOfSpeech: "noun", Gloss: "money lender"},
+				LexiconEntry{Lemma: "bank", PartOfSpeech: "noun", Gloss: "row of things"},
+				LexiconEntry{Lemma: "beach", PartOfSpeech: "noun"},
+			)).WithCommonness(1, 0)
+
+			total := 0.0
+
+			for _, entry := range senses.Pool("noun", "b") {
+				if entry.Lemma == "bank" {
+					total += entry.Weight
+				}
+			}
+
+			Expect(total).To(BeNumerically("~", 1, 0.0001))
+			Expect(senses.Pool("noun", "b")[3].Weight).To(Equal(1.0))
+		})
+		It("Leaves out words rarer than the cutoff", func() {
+			actual := lexicon.WithCommonness(0, 2)
+
+			Expect(actual.Pool("noun", "a")).To(Equal([]LexiconEntry{
+				{Lemma: "apple", PartOfSpeech: "noun", Frequency: 99},
+			}))
+		})
+		It("Leaves lexicons without frequencies alone", func() {
+			plain := NewLexicon(LexiconEntry{Lemma: "acorn", PartOfSpeech: "noun"})
+
+			Expect(plain.WithCommonness(1, 5)).To(BeIdenticalTo(plain))
+		})
+	})
+})
+
+func ExampleLexicon_WithCommonness() {
+	frequencies, _ := ReadFrequencyList(strings.NewReader("apple 99\nacorn 1\n"))
+	lexicon := frequencies.Apply(NewLexicon(
+		LexiconEntry{Lemma: "acorn", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+	))
+
+	for _, entry := range lexicon.WithCommonness(1, 0).Pool("noun", "a") {
+		fmt.Println(entry.Lemma, entry.Weight)
+	}
+	// Output:
+	// acorn 0.02
+	// apple 1
+}
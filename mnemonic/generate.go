@@ -0,0 +1,497 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrNoDictionary is returned by Generate when it hasn't been told where to get words from, and the program was
+// built without the embedded lexicon
+var ErrNoDictionary = errors.New("no dictionary or word generators given")
+
+// DictionaryError is returned by Generate when the dictionary can't be loaded
+type DictionaryError struct {
+	Path string
+	Err  error
+}
+
+// Error describes which dictionary couldn't be loaded and why
+func (e *DictionaryError) Error() string {
+	return fmt.Sprintf("could not load dictionary %q: %s", e.Path, e.Err)
+}
+
+// Option changes how Generate makes a mnemonic
+type Option func(*generateOptions)
+
+// generateOptions is everything the options can change
+type generateOptions struct {
+	sources     []dictionarySource
+	indexCache  string
+	overlay     *Overlay
+	chance      float64
+	frequencies []string
+	commonness  float64
+	minimum     int
+	content     *ContentFilter
+	domains     []string
+	theme       string
+	grammar     *Grammar
+	minWords    int
+	maxWords    int
+	generators  []WordGeneratorV2
+	seed        int64
+	seedSet     bool
+	style       TemplateStyle
+	filters     []WordFilter
+	escaping    Escaping
+	placeholder string
+}
+
+// dictionarySource is a dictionary to load, or a lexicon we were given, and how much its words count for
+type dictionarySource struct {
+	path    string
+	lexicon *Lexicon
+	weight  float64
+}
+
+// WithDictionary loads words from WordNet, either a directory of dictionary files or an LMF XML file, a directory of
+// word lists, or a CSV file
+//
+// See LoadLexicon for the formats. It can be given more than once to merge dictionaries, see WithWeightedDictionary.
+// An empty path is ignored. Without a dictionary or word generators the embedded lexicon is used.
+func WithDictionary(path string) Option {
+	return WithWeightedDictionary(path, 1)
+}
+
+// WithWeightedDictionary loads words from a dictionary like WithDictionary, making its words weight times as likely
+//
+// When a word is in more than one dictionary, or lexicon from WithLexicon, the one given first is used.
+func WithWeightedDictionary(path string, weight float64) Option {
+	return func(o *generateOptions) {
+		if path != "" {
+			o.sources = append(o.sources, dictionarySource{path: path, weight: weight})
+		}
+	}
+}
+
+// WithLexicon gets words from a lexicon that's already loaded, or built in code with NewLexicon
+//
+// Like WithDictionary it can be given more than once, or along with dictionaries, to merge them.
+func WithLexicon(lexicon *Lexicon) Option {
+	return func(o *generateOptions) {
+		o.sources = append(o.sources, dictionarySource{lexicon: lexicon, weight: 1})
+	}
+}
+
+// WithOverlay merges an overlay into the dictionary, trying the overlay's words first with the given chance
+//
+// It only changes words from a dictionary or lexicon, not word generators.
+func WithOverlay(overlay *Overlay, chance float64) Option {
+	return func(o *generateOptions) {
+		o.overlay = overlay
+		o.chance = chance
+	}
+}
+
+// WithFrequencyList sets how often words are used from a frequency list file, see ReadFrequencyList for the format
+//
+// It can be given more than once, and goes along with any frequencies the dictionary has, like WordNet's cntlist.
+func WithFrequencyList(path string) Option {
+	return func(o *generateOptions) {
+		o.frequencies = append(o.frequencies, path)
+	}
+}
+
+// WithCommonness sets how strongly words that are used more are preferred, 0 ignores how common words are
+//
+// It's 1 unless it's set, see Lexicon.WithCommonness.
+func WithCommonness(commonness float64) Option {
+	return func(o *generateOptions) {
+		o.commonness = commonness
+	}
+}
+
+// WithMinFrequency leaves out words used less than minimum times, when the dictionary knows how often words are used
+func WithMinFrequency(minimum int) Option {
+	return func(o *generateOptions) {
+		o.minimum = minimum
+	}
+}
+
+// WithContentFilter leaves out the words the filter blocks, see NewSafeContentFilter
+//
+// Blocked words are taken out of the dictionary, and words from word generators are checked too.
+func WithContentFilter(filter *ContentFilter) Option {
+	return func(o *generateOptions) {
+		o.content = filter
+	}
+}
+
+// WithDomains only uses words in the domains, like noun.animal or noun.food, see Lexicon.WithDomains
+//
+// It can be given more than once to add domains. Only words from a dictionary, lexicon or overlay are restricted,
+// see Overlay.WithDomains for what happens to the overlay's words.
+func WithDomains(domains ...string) Option {
+	return func(o *generateOptions) {
+		o.domains = append(o.domains, domains...)
+	}
+}
+
+// WithTheme prefers words near the topic, like ocean or kitchen, so the mnemonic makes a picture
+//
+// Words are near the topic when WordNet relates them to it, they're tagged with it, or their gloss mentions it.
+// Other words are only used for letters that have no words near the topic.
+func WithTheme(topic string) Option {
+	return func(o *generateOptions) {
+		o.theme = topic
+	}
+}
+
+// WithIndexCache keeps an index of the dictionary in a directory, so it loads faster next time
+func WithIndexCache(cacheDir string) Option {
+	return func(o *generateOptions) {
+		o.indexCache = cacheDir
+	}
+}
+
+// WithWordGenerators uses the given generators for words instead of a dictionary
+func WithWordGenerators(generators ...WordGeneratorV2) Option {
+	return func(o *generateOptions) {
+		o.generators = generators
+	}
+}
+
+// WithSeed picks words using the seed, the same seed, input and dictionary always give the same mnemonic
+func WithSeed(seed int64) Option {
+	return func(o *generateOptions) {
+		o.seed = seed
+		o.seedSet = true
+	}
+}
+
+// WithGrammar lays the mnemonic out using a grammar rather than a template style, see Grammar
+func WithGrammar(grammar *Grammar) Option {
+	return func(o *generateOptions) {
+		o.grammar = grammar
+	}
+}
+
+// WithSentenceLength sets the fewest and most words in a sentence of prose, see StyleProse
+func WithSentenceLength(minWords int, maxWords int) Option {
+	return func(o *generateOptions) {
+		o.minWords = minWords
+		o.maxWords = maxWords
+	}
+}
+
+// WithTemplateStyle lays the mnemonic out in the given style, rather than as prose
+func WithTemplateStyle(style TemplateStyle) Option {
+	return func(o *generateOptions) {
+		o.style = style
+	}
+}
+
+// WithFilter only uses words the filter keeps, it can be given more than once
+func WithFilter(filter WordFilter) Option {
+	return func(o *generateOptions) {
+		o.filters = append(o.filters, filter)
+	}
+}
+
+// WithOutputFormat escapes the mnemonic's String for where it's going
+func WithOutputFormat(escaping Escaping) Option {
+	return func(o *generateOptions) {
+		o.escaping = escaping
+	}
+}
+
+// WithPlaceholder uses the placeholder for letters that have no word, rather than failing
+func WithPlaceholder(placeholder string) Option {
+	return func(o *generateOptions) {
+		o.placeholder = placeholder
+	}
+}
+
+// Generate returns a mnemonic for the input
+//
+// Might be used like this
+//   result, err := mnemonic.Generate(ctx, "ROYGBIV", mnemonic.WithDictionary("/tmp/dict"), mnemonic.WithSeed(42))
+//   fmt.Println(result)
+func Generate(ctx context.Context, input string, opts ...Option) (*Mnemonic, error) {
+	alternatives, err := GenerateAlternatives(ctx, input, 1, opts...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return alternatives[0], nil
+}
+
+// GenerateAlternatives returns up to count different mnemonics for the input, the dictionary is only loaded once
+//
+// Might be used like this
+//   results, err := mnemonic.GenerateAlternatives(ctx, "ROYGBIV", 5, mnemonic.WithDictionary("/tmp/dict"))
+func GenerateAlternatives(ctx context.Context, input string, count int, opts ...Option) ([]*Mnemonic, error) {
+	o := &generateOptions{
+		style:      StyleProse,
+		seed:       time.Now().UnixNano(),
+		commonness: 1,
+		minWords:   DefaultMinWords,
+		maxWords:   DefaultMaxWords,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	letters := strings.Split(strings.ToLower(input), "")
+	var lexicon *Lexicon
+	var theme *Theme
+	var pools PoolSizer = newGeneratorPools(o.generators)
+
+	if len(o.generators) == 0 {
+		merged, err := o.mergedLexicon()
+
+		if err != nil {
+			return nil, err
+		}
+
+		lexicon, err = o.lexicon(merged)
+
+		if err != nil {
+			return nil, err
+		}
+
+		theme = o.loadTheme(merged)
+		pools = lexicon
+	}
+
+	template, err := o.template(letters, pools)
+
+	if err != nil {
+		return nil, err
+	}
+
+	generators := o.wordGenerators(lexicon, theme, NewSeededRandomSource(o.seed))
+	parser := NewTemplateParserV2(generators...)
+	parser.SetPlaceholder(o.placeholder)
+
+	results, err := parser.GenerateAlternatives(ctx, template, letters, count)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range results {
+		results[i].Seed = o.seed
+		results[i].escaping = o.escaping
+	}
+
+	return results, nil
+}
+
+// template lays the letters out, with the grammar if there is one or otherwise the template style
+//
+// The layout is planned around the words there are, see Grammar.Plan. If there's no plan the letters are laid out
+// without it, so the letter that has no word can be reported or given a placeholder.
+func (o *generateOptions) template(letters []string, pools PoolSizer) (Template, error) {
+	var grammar *Grammar
+	var err error
+
+	switch {
+	case o.grammar != nil:
+		grammar = o.grammar
+	case o.style == StyleProse:
+		grammar, err = NewProseGrammar(o.minWords, o.maxWords)
+	default:
+		grammar, err = StyleGrammar(o.style)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := grammar.Plan(letters, pools, NewSeededRandomSource(o.seed))
+
+	if _, ok := err.(*NoPlanError); !ok {
+		return template, err
+	}
+
+	template, err = grammar.Expand(letters, NewSeededRandomSource(o.seed))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// wordGenerators returns the generators to use, getting words from the lexicon if we weren't given any, near the
+// theme if there is one
+func (o *generateOptions) wordGenerators(lexicon *Lexicon, theme *Theme, random RandomSource) []WordGeneratorV2 {
+	generators := o.generators
+
+	if len(generators) == 0 {
+		generators = NewLexiconWordGenerators(lexicon, random)
+
+		if o.overlay != nil {
+			generators = NewOverlayWordGenerators(lexicon, o.overlay.WithDomains(lexicon, o.domains...), o.chance, random)
+		}
+
+		if theme != nil {
+			generators = NewThemedWordGenerators(theme.Apply(lexicon), generators, random)
+		}
+	}
+
+	filters := append([]WordFilter{}, o.filters...)
+
+	if o.content != nil {
+		filters = append(filters, o.content.Keep)
+	}
+
+	if len(filters) == 0 {
+		return generators
+	}
+
+	filtered := []WordGeneratorV2{}
+
+	for i := range generators {
+		filtered = append(filtered, NewFilteredWordGenerator(generators[i], filters...))
+	}
+
+	return filtered
+}
+
+// generatorPools says every letter has plenty of words for the parts of speech there are word generators for
+type generatorPools map[string]bool
+
+// newGeneratorPools returns the pools for the parts of speech of the word generators
+func newGeneratorPools(generators []WordGeneratorV2) generatorPools {
+	pools := make(generatorPools)
+
+	for _, generator := range generators {
+		pools[generator.GetFuncName()] = true
+	}
+
+	return pools
+}
+
+// PoolSize returns plenty if there's a word generator for the part of speech, and none otherwise
+func (p generatorPools) PoolSize(partOfSpeech string, letter string) int {
+	if p[partOfSpeech] {
+		return plentifulPool
+	}
+
+	return 0
+}
+
+// lexicon returns the words of the dictionaries to use, weighted by how common they are, with the closed-class words
+// if the dictionary doesn't have its own and isn't kept to domains
+func (o *generateOptions) lexicon(lexicon *Lexicon) (*Lexicon, error) {
+	for _, path := range o.frequencies {
+		frequencies, err := LoadFrequencyList(path)
+
+		if err != nil {
+			return nil, &DictionaryError{Path: path, Err: err}
+		}
+
+		lexicon = frequencies.Apply(lexicon)
+	}
+
+	if o.content != nil {
+		lexicon = o.content.Apply(lexicon)
+	}
+
+	lexicon, err := lexicon.WithDomains(o.domains...)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lexicon = lexicon.WithCommonness(o.commonness, o.minimum)
+
+	// Function words aren't in any domain, so they'd be the only words for letters the domains leave out
+	if len(o.domains) > 0 {
+		return lexicon, nil
+	}
+
+	return lexicon.withPartsOfSpeech(ClosedClassLexicon()), nil
+}
+
+// mergedLexicon returns the lexicons we were given and the dictionaries merged, or the embedded lexicon if there are
+// none
+func (o *generateOptions) mergedLexicon() (*Lexicon, error) {
+	if len(o.sources) == 0 {
+		lexicon, err := EmbeddedLexicon()
+
+		if err == ErrNoEmbeddedLexicon {
+			return nil, ErrNoDictionary
+		}
+
+		return lexicon, err
+	}
+
+	sources := []LexiconSource{}
+
+	for _, source := range o.sources {
+		lexicon := source.lexicon
+
+		if lexicon == nil {
+			var err error
+			lexicon, err = o.loadDictionary(source.path)
+
+			if err != nil {
+				return nil, &DictionaryError{Path: source.path, Err: err}
+			}
+		}
+
+		sources = append(sources, LexiconSource{Lexicon: lexicon, Weight: source.weight})
+	}
+
+	if len(sources) == 1 && sources[0].Weight == 1 {
+		return sources[0].Lexicon, nil
+	}
+
+	return MergeLexicons(sources...), nil
+}
+
+// loadTheme returns the theme if there is one, with the relations between the dictionaries' words
+//
+// It's worked out before any words are left out, so a word that's filtered out can still link the topic to others.
+func (o *generateOptions) loadTheme(lexicon *Lexicon) *Theme {
+	if o.theme == "" {
+		return nil
+	}
+
+	theme := NewTheme(o.theme)
+	theme.AddRelations(lexicon)
+
+	return theme
+}
+
+// loadDictionary loads a dictionary, from the index cache if it's WordNet and there is one
+func (o *generateOptions) loadDictionary(path string) (*Lexicon, error) {
+	if o.indexCache != "" && IsWordNetDir(path) {
+		return LoadCachedWordNetLexicon(path, o.indexCache)
+	}
+
+	return LoadLexicon(path)
+}
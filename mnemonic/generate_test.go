@@ -0,0 +1,310 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// testGenerators returns a generator for each part of speech that makes up a word from the letter
+func testGenerators() []WordGeneratorV2 {
+	generators := []WordGeneratorV2{}
+
+	for _, partOfSpeech := range []string{"adj", "noun", "verb", "adv"} {
+		generators = append(generators, NewWordGeneratorAdapter(&testWordGenerator{
+			funcName: partOfSpeech,
+			function: func(partOfSpeech string) func(string) string {
+				return func(letter string) string {
+					return letter + "-" + partOfSpeech
+				}
+			}(partOfSpeech),
+		}))
+	}
+
+	return generators
+}
+
+var _ = Describe("Generate", func() {
+	It("Uses the embedded lexicon when there's no dictionary", func() {
+		actual, err := Generate(context.Background(), "abc", WithSeed(42))
+
+		if _, embeddedErr := EmbeddedLexicon(); embeddedErr != nil {
+			Expect(err).To(Equal(ErrNoDictionary))
+
+			return
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Words).To(HaveLen(3))
+		Expect(actual.Words[0].Source).To(Equal("embedded"))
+	})
+	It("Uses a lexicon it's given", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "bouncy", PartOfSpeech: "adj"},
+		)
+
+		actual, err := Generate(context.Background(), "ba", WithLexicon(lexicon))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("Bouncy apple."))
+	})
+	It("Merges dictionaries, the first given taking precedence", func() {
+		first := NewLexicon(LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Source: "first"})
+		second := NewLexicon(
+			LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Source: "second"},
+			LexiconEntry{Lemma: "bouncy", PartOfSpeech: "adj", Source: "second"},
+		)
+
+		actual, err := Generate(context.Background(), "ba", WithLexicon(first), WithLexicon(second))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("Bouncy apple."))
+		Expect(actual.Words[0].Source).To(Equal("second"))
+		Expect(actual.Words[1].Source).To(Equal("first"))
+	})
+	It("Says which of several dictionaries couldn't be loaded", func() {
+		_, err := Generate(
+			context.Background(),
+			"abc",
+			WithLexicon(NewLexicon()),
+			WithDictionary(""),
+			WithWeightedDictionary("/does/not/exist", 2),
+		)
+
+		Expect(err).To(BeAssignableToTypeOf(&DictionaryError{}))
+		Expect(err.(*DictionaryError).Path).To(Equal("/does/not/exist"))
+	})
+	It("Leaves out rare words", func() {
+		dir, err := ioutil.TempDir("", "mnemonic-frequency")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "frequencies.txt")
+		Expect(ioutil.WriteFile(path, []byte("apple 120\nacorn 1\n"), 0644)).To(Succeed())
+
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "acorn", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"a",
+				WithLexicon(lexicon),
+				WithFrequencyList(path),
+				WithMinFrequency(2),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("Apple."))
+		}
+	})
+	It("Only uses words in the domains", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "anvil", PartOfSpeech: "noun", Domain: "noun.artifact"},
+			LexiconEntry{Lemma: "aardvark", PartOfSpeech: "noun", Domain: "noun.animal"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"a",
+				WithLexicon(lexicon),
+				WithDomains("noun.animal"),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("Aardvark."))
+		}
+	})
+	It("Merges in an overlay", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "bouncy", PartOfSpeech: "adj"},
+		)
+		overlay := NewOverlay(
+			OverlayEntry{Action: OverlayAdd, LexiconEntry: LexiconEntry{Lemma: "acme", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlaySuppress, LexiconEntry: LexiconEntry{Lemma: "apple"}},
+		)
+
+		actual, err := Generate(context.Background(), "ba", WithLexicon(lexicon), WithOverlay(overlay, 0.5))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("Bouncy acme."))
+	})
+	It("Keeps the overlay's words to the domains", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "aardvark", PartOfSpeech: "noun", Domain: "noun.animal"},
+			LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Domain: "noun.food"},
+		)
+		overlay := NewOverlay(
+			OverlayEntry{Action: OverlayAdd, LexiconEntry: LexiconEntry{Lemma: "acme", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlayBoost, LexiconEntry: LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"}},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"a",
+				WithLexicon(lexicon),
+				WithOverlay(overlay, 1),
+				WithDomains("noun.animal"),
+				WithTemplateStyle(StyleList),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Words[0].Lemma).To(Equal("aardvark"))
+		}
+	})
+	It("Wraps dictionaries that can't be loaded", func() {
+		_, err := Generate(context.Background(), "abc", WithDictionary("/does/not/exist"))
+
+		Expect(err).To(BeAssignableToTypeOf(&DictionaryError{}))
+	})
+	It("Lower cases the input and lays it out in sentences", func() {
+		actual, err := Generate(
+			context.Background(),
+			"ABCDE",
+			WithWordGenerators(testGenerators()...),
+			WithTemplateStyle(StyleSentence),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("a-adj b-noun c-verb d-adv. e-noun."))
+	})
+	It("Lays the mnemonic out as prose unless told otherwise", func() {
+		for seed := int64(0); seed < 10; seed++ {
+			expected, err := Generate(
+				context.Background(),
+				"abcdefgh",
+				WithWordGenerators(testGenerators()...),
+				WithTemplateStyle(StyleProse),
+				WithSeed(seed),
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			actual, err := Generate(context.Background(), "abcdefgh", WithWordGenerators(testGenerators()...), WithSeed(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal(expected.Text()))
+		}
+	})
+	It("Rejects unknown styles", func() {
+		_, err := Generate(
+			context.Background(),
+			"abc",
+			WithWordGenerators(testGenerators()...),
+			WithTemplateStyle("limerick"),
+		)
+
+		Expect(err).To(MatchError(`unknown template style "limerick"`))
+	})
+	It("Lays the mnemonic out with a grammar", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun "and" noun`))
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := Generate(context.Background(), "ab", WithWordGenerators(testGenerators()...), WithGrammar(grammar))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("a-noun and b-noun"))
+		Expect(actual.Words[1].Index).To(Equal(1))
+	})
+	It("Records the seed", func() {
+		actual, err := Generate(context.Background(), "abc", WithWordGenerators(testGenerators()...), WithSeed(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Seed).To(Equal(int64(42)))
+	})
+	It("Applies the filters", func() {
+		_, err := Generate(
+			context.Background(),
+			"abc",
+			WithWordGenerators(testGenerators()...),
+			WithFilter(func(word Word) bool { return !strings.HasPrefix(word.Lemma, "b") }),
+		)
+
+		Expect(err).To(Equal(&NoWordError{Letter: "b", Position: 2}))
+	})
+	It("Uses the placeholder", func() {
+		actual, err := Generate(
+			context.Background(),
+			"abc",
+			WithWordGenerators(testGenerators()...),
+			WithFilter(func(word Word) bool { return !strings.HasPrefix(word.Lemma, "b") }),
+			WithPlaceholder("?"),
+			WithTemplateStyle(StyleSentence),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("a-adj ? c-verb."))
+	})
+	It("Formats the output", func() {
+		actual, err := Generate(
+			context.Background(),
+			"ab",
+			WithWordGenerators(testGenerators()...),
+			WithOutputFormat(EscapeJSON),
+			WithTemplateStyle(StyleSentence),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.String()).To(Equal(`"a-adj b-noun."`))
+		Expect(actual.Text()).To(Equal("a-adj b-noun."))
+	})
+	It("Returns alternatives", func() {
+		actual, err := GenerateAlternatives(context.Background(), "ab", 3, WithWordGenerators(testGenerators()...))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(HaveLen(1))
+	})
+})
+
+func ExampleGenerate() {
+	result, err := Generate(
+		context.Background(),
+		"demo",
+		WithWordGenerators(
+			NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj")),
+			NewWordGeneratorAdapter(NewStaticWordGenerator("eggs", "noun")),
+			NewWordGeneratorAdapter(NewStaticWordGenerator("move", "verb")),
+			NewWordGeneratorAdapter(NewStaticWordGenerator("outward", "adv")),
+		),
+		WithSeed(42),
+	)
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	fmt.Println(result)
+	// Output: Eggs move dancing eggs.
+}
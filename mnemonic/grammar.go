@@ -0,0 +1,828 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// defaultGrammar lays letters out in sentences of four words, an adjective, a noun, a verb and an adverb, with
+// whatever is left over in a shorter sentence at the end
+const defaultGrammar = `
+mnemonic = sentence | full mnemonic
+full     = adj noun verb adv .
+sentence = noun . | adj noun . | adj noun verb . | full
+`
+
+// grammarPunctuation is the punctuation that can go after a word
+const grammarPunctuation = ".?!,:;"
+
+// grammarSymbolKind is what a symbol in a grammar stands for
+type grammarSymbolKind int
+
+const (
+	// symbolRule is another rule of the grammar
+	symbolRule grammarSymbolKind = iota
+	// symbolWord is a word for the next letter, from the template function of the same name
+	symbolWord
+	// symbolLiteral is text used as it is, without using up a letter
+	symbolLiteral
+	// symbolPunctuation goes straight after the word before it
+	symbolPunctuation
+	// symbolLineBreak starts a new line after the word before it
+	symbolLineBreak
+)
+
+// grammarSymbol is one part of an alternative
+type grammarSymbol struct {
+	kind grammarSymbolKind
+	text string
+	// title is true when the words the symbol stands for start with a capital letter
+	title bool
+}
+
+// lastResortWeight is the weight at or below which an alternative is only planned with when nothing else fits
+const lastResortWeight = 0.001
+
+// grammarAlternative is one way a rule can be expanded, and how likely it is to be picked
+type grammarAlternative struct {
+	id      int
+	symbols []grammarSymbol
+	weight  float64
+}
+
+// Grammar is a weighted context-free grammar describing how a mnemonic is laid out
+//
+// Each line is a rule, a name then = then alternatives separated by |, and a line starting with | adds more
+// alternatives to the rule above. The first rule is where a mnemonic starts. An alternative is a list of symbols
+// with an optional weight in square brackets, which makes it that many times as likely to be picked. An alternative
+// weighted 0.001 or less is a last resort, that Plan only uses when there's no layout without one.
+//
+// A symbol is another rule, a part of speech like noun that is a word for the next letter, quoted text like "the"
+// that's used as it is without using up a letter, punctuation . ? ! , : or ; that goes straight after the word
+// before it, or / to start a new line. A ^ before a symbol capitalises the first letter of each word and quoted
+// text it stands for. Anything after # is a comment.
+//
+//   mnemonic = sentence | sentence mnemonic
+//   sentence = adj noun verb adv . [3]
+//            | "the" noun verb .
+//            | noun verb noun ?
+type Grammar struct {
+	start        string
+	rules        map[string][]grammarAlternative
+	alternatives int
+}
+
+// NoExpansionError is returned when a grammar can't lay out a mnemonic with that many letters
+type NoExpansionError struct {
+	Letters int
+}
+
+// Error says how many letters the grammar couldn't lay out
+func (e *NoExpansionError) Error() string {
+	return fmt.Sprintf("the grammar has no layout for %d letters", e.Letters)
+}
+
+// DefaultGrammar returns the grammar NewTemplate uses, sentences of an adjective, a noun, a verb and an adverb
+func DefaultGrammar() *Grammar {
+	grammar, err := ReadGrammar(strings.NewReader(defaultGrammar))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return grammar
+}
+
+// LoadGrammar loads a grammar from a file, see Grammar for the format
+//
+// Could be used like
+//   grammar, err := mnemonic.LoadGrammar("/tmp/grammar.txt")
+func LoadGrammar(path string) (*Grammar, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	return ReadGrammar(file)
+}
+
+// ReadGrammar reads a grammar, see Grammar for the format
+//
+// Could be used like
+//   grammar, err := mnemonic.ReadGrammar(strings.NewReader("mnemonic = noun | noun mnemonic"))
+func ReadGrammar(reader io.Reader) (*Grammar, error) {
+	grammar := &Grammar{rules: make(map[string][]grammarAlternative)}
+	scanner := bufio.NewScanner(reader)
+	rule := ""
+
+	for line := 1; scanner.Scan(); line++ {
+		tokens, err := tokenizeGrammarLine(scanner.Text())
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+
+		if len(tokens) == 0 {
+			continue
+		}
+
+		switch {
+		case len(tokens) > 1 && tokens[1] == "=" && isGrammarName(tokens[0]):
+			rule = tokens[0]
+			tokens = tokens[2:]
+		case tokens[0] == "|" && rule != "":
+			tokens = tokens[1:]
+		default:
+			return nil, fmt.Errorf("line %d: expected a rule name then =", line)
+		}
+
+		if grammar.start == "" {
+			grammar.start = rule
+		}
+
+		if err := grammar.addAlternatives(rule, tokens); err != nil {
+			return nil, fmt.Errorf("line %d: %s", line, err)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if grammar.start == "" {
+		return nil, fmt.Errorf("the grammar has no rules")
+	}
+
+	grammar.resolveRules()
+
+	return grammar, nil
+}
+
+// addAlternatives adds the alternatives in the tokens, separated by |, to a rule
+func (g *Grammar) addAlternatives(rule string, tokens []string) error {
+	alternative := grammarAlternative{weight: 1}
+	weighted := false
+	title := false
+
+	for _, token := range append(tokens, "|") {
+		switch {
+		case title && !isGrammarName(token) && !strings.HasPrefix(token, `"`) && !strings.HasPrefix(token, "'"):
+			return fmt.Errorf("^ before %q", token)
+		case token == "|":
+			alternative.id = g.alternatives
+			g.alternatives++
+			g.rules[rule] = append(g.rules[rule], alternative)
+			alternative = grammarAlternative{weight: 1}
+			weighted = false
+		case weighted:
+			return fmt.Errorf("%q after the weight", token)
+		case strings.HasPrefix(token, "["):
+			weight, err := strconv.ParseFloat(strings.Trim(token, "[]"), 64)
+
+			if err != nil || weight <= 0 {
+				return fmt.Errorf("weight %s isn't a number above 0", token)
+			}
+
+			alternative.weight = weight
+			weighted = true
+		case token == "^":
+			title = true
+		case strings.HasPrefix(token, `"`) || strings.HasPrefix(token, "'"):
+			alternative.symbols = append(alternative.symbols, grammarSymbol{
+				kind:  symbolLiteral,
+				text:  token[1 : len(token)-1],
+				title: title,
+			})
+			title = false
+		case token == "/":
+			alternative.symbols = append(alternative.symbols, grammarSymbol{kind: symbolLineBreak, text: token})
+		case strings.ContainsAny(token, grammarPunctuation):
+			alternative.symbols = append(alternative.symbols, grammarSymbol{kind: symbolPunctuation, text: token})
+		case isGrammarName(token):
+			alternative.symbols = append(alternative.symbols, grammarSymbol{kind: symbolWord, text: token, title: title})
+			title = false
+		default:
+			return fmt.Errorf("unexpected %q", token)
+		}
+	}
+
+	return nil
+}
+
+// resolveRules marks the symbols that name a rule, the rest are parts of speech
+func (g *Grammar) resolveRules() {
+	for _, alternatives := range g.rules {
+		for i := range alternatives {
+			for j := range alternatives[i].symbols {
+				symbol := &alternatives[i].symbols[j]
+
+				if _, ok := g.rules[symbol.text]; ok && symbol.kind == symbolWord {
+					symbol.kind = symbolRule
+				}
+			}
+		}
+	}
+}
+
+// tokenizeGrammarLine splits a line of a grammar into names, =, |, quoted text, weights and punctuation
+func tokenizeGrammarLine(line string) ([]string, error) {
+	tokens := []string{}
+	runes := []rune(line)
+
+	for i := 0; i < len(runes); i++ {
+		switch current := runes[i]; {
+		case unicode.IsSpace(current):
+			continue
+		case current == '#':
+			return tokens, nil
+		case current == '"' || current == '\'' || current == '[':
+			closing := current
+
+			if current == '[' {
+				closing = ']'
+			}
+
+			end := i + 1
+
+			for end < len(runes) && runes[end] != closing {
+				end++
+			}
+
+			if end == len(runes) {
+				return nil, fmt.Errorf("%q isn't closed", current)
+			}
+
+			tokens = append(tokens, string(runes[i:end+1]))
+			i = end
+		case strings.ContainsRune("=|/^"+grammarPunctuation, current):
+			tokens = append(tokens, string(current))
+		case isGrammarNameRune(current):
+			end := i
+
+			for end < len(runes) && isGrammarNameRune(runes[end]) {
+				end++
+			}
+
+			tokens = append(tokens, string(runes[i:end]))
+			i = end - 1
+		default:
+			return nil, fmt.Errorf("unexpected %q", current)
+		}
+	}
+
+	return tokens, nil
+}
+
+// isGrammarName returns true if the token can name a rule or template function
+func isGrammarName(token string) bool {
+	for i, current := range token {
+		if !isGrammarNameRune(current) || (i == 0 && unicode.IsDigit(current)) {
+			return false
+		}
+	}
+
+	return token != ""
+}
+
+// isGrammarNameRune returns true for the letters, digits and underscores names are made of
+func isGrammarNameRune(current rune) bool {
+	return current == '_' || unicode.IsLetter(current) || unicode.IsDigit(current)
+}
+
+// Expand lays the letters out using the grammar, returning a template with a word for each letter
+//
+// Where the grammar has more than one way to lay out that many letters one is picked using random, weighted by the
+// alternatives' weights, so the same seed always gives the same layout.
+//
+// Might be used like this:
+//   template, err := grammar.Expand([]string{"e", "x", "a", "m", "p", "l", "e"}, mnemonic.NewSeededRandomSource(42))
+func (g *Grammar) Expand(letters []string, random RandomSource) (*TemplateBase, error) {
+	if len(letters) == 0 {
+		return NewTemplateFromSlots(nil), nil
+	}
+
+	expansion := newGrammarExpansion(g, letters, random, func(int, string) bool { return true })
+
+	if !expansion.ruleFits(g.start, 0, len(letters)) {
+		return nil, &NoExpansionError{Letters: len(letters)}
+	}
+
+	symbols := expansion.expandRule(g.start, 0, len(letters))
+
+	return NewTemplateFromSlots(grammarSlots(symbols, letters)), nil
+}
+
+// grammarExpansion is the state of laying out letters with a grammar
+//
+// A rule that comes back round to itself without laying out a letter isn't followed round again, so every layout
+// has a finite number of steps.
+type grammarExpansion struct {
+	grammar *Grammar
+	letters []string
+	random  RandomSource
+	// allowed says whether a word for a part of speech can stand for the letter at an index
+	allowed func(index int, partOfSpeech string) bool
+	// avoidLastResorts leaves out the alternatives weighted lastResortWeight or less
+	avoidLastResorts bool
+	// lengths are the numbers of letters each rule could lay out if every word were allowed
+	lengths map[string][]int
+	// ruleIDs number the rules, for their expansionKeys
+	ruleIDs map[string]int
+	// tails say which numbers of letters each alternative could lay out from a position on, by id then position
+	tails [][][]bool
+	// fits says whether each rule, or alternative from a position on, can lay out some of the letters
+	fits map[expansionKey]bool
+	// insides are how likely each rule, or alternative from a position on, is to lay out some of the letters
+	insides map[expansionKey]float64
+}
+
+// expansionKey is a rule, or an alternative from a position on, laying out count letters from start
+//
+// A rule's alternative is -1 less its number in ruleIDs, so keys are all numbers and quick to look up.
+type expansionKey struct {
+	alternative int
+	position    int
+	start       int
+	count       int
+}
+
+// pickResolution is how finely a choice is split up between the things it's picking from
+const pickResolution = 1 << 30
+
+// newGrammarExpansion returns an expansion of the letters that only puts words where allowed says they can go
+func newGrammarExpansion(
+	grammar *Grammar,
+	letters []string,
+	random RandomSource,
+	allowed func(index int, partOfSpeech string) bool,
+) *grammarExpansion {
+	derivable := derivableLengths(grammar, len(letters))
+
+	return &grammarExpansion{
+		grammar: grammar,
+		letters: letters,
+		random:  random,
+		allowed: allowed,
+		lengths: ruleLengths(derivable),
+		ruleIDs: ruleIDs(grammar),
+		tails:   alternativeTails(grammar, derivable, len(letters)),
+		fits:    make(map[expansionKey]bool),
+		insides: make(map[expansionKey]float64),
+	}
+}
+
+// ruleIDs returns a different number for each rule of the grammar
+func ruleIDs(grammar *Grammar) map[string]int {
+	ids := make(map[string]int)
+
+	for rule := range grammar.rules {
+		ids[rule] = len(ids)
+	}
+
+	return ids
+}
+
+// derivableLengths returns which numbers of letters, up to length, each rule could lay out if every word were allowed
+//
+// It's the same wherever the letters start, so it's quick to work out and saves trying lengths that can't fit.
+func derivableLengths(grammar *Grammar, length int) map[string][]bool {
+	derivable := make(map[string][]bool)
+
+	for rule := range grammar.rules {
+		derivable[rule] = make([]bool, length+1)
+	}
+
+	// Rules can refer to each other in a loop, so keep going until nothing new can be derived
+	for changed := true; changed; {
+		changed = false
+
+		for rule, alternatives := range grammar.rules {
+			for _, alternative := range alternatives {
+				for count, derived := range sequenceLengths(alternative.symbols, derivable, length) {
+					if derived && !derivable[rule][count] {
+						derivable[rule][count] = true
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	return derivable
+}
+
+// ruleLengths returns the numbers of letters each rule could lay out, smallest first
+func ruleLengths(derivable map[string][]bool) map[string][]int {
+	lengths := make(map[string][]int)
+
+	for rule := range derivable {
+		lengths[rule] = []int{}
+
+		for count, derived := range derivable[rule] {
+			if derived {
+				lengths[rule] = append(lengths[rule], count)
+			}
+		}
+	}
+
+	return lengths
+}
+
+// sequenceLengths returns which numbers of letters, up to length, the symbols can lay out together
+func sequenceLengths(symbols []grammarSymbol, derivable map[string][]bool, length int) []bool {
+	lengths := make([]bool, length+1)
+	lengths[0] = true
+
+	for _, symbol := range symbols {
+		lengths = addSymbolLengths(symbol, lengths, derivable)
+	}
+
+	return lengths
+}
+
+// addSymbolLengths returns which numbers of letters the symbol and whatever laid out the lengths can lay out together
+func addSymbolLengths(symbol grammarSymbol, lengths []bool, derivable map[string][]bool) []bool {
+	next := make([]bool, len(lengths))
+
+	for count, ok := range lengths {
+		switch {
+		case !ok:
+		case symbol.kind == symbolRule:
+			for extra := 0; count+extra < len(lengths); extra++ {
+				next[count+extra] = next[count+extra] || derivable[symbol.text][extra]
+			}
+		case symbol.kind == symbolWord:
+			if count+1 < len(lengths) {
+				next[count+1] = true
+			}
+		default:
+			next[count] = true
+		}
+	}
+
+	return next
+}
+
+// alternativeTails returns which numbers of letters, up to length, each alternative could lay out from each
+// position on, so an alternative that can't fit is given up on straight away
+func alternativeTails(grammar *Grammar, derivable map[string][]bool, length int) [][][]bool {
+	tails := make([][][]bool, grammar.alternatives)
+
+	for _, alternatives := range grammar.rules {
+		for _, alternative := range alternatives {
+			tail := make([][]bool, len(alternative.symbols)+1)
+			tail[len(alternative.symbols)] = make([]bool, length+1)
+			tail[len(alternative.symbols)][0] = true
+
+			for position := len(alternative.symbols) - 1; position >= 0; position-- {
+				tail[position] = addSymbolLengths(alternative.symbols[position], tail[position+1], derivable)
+			}
+
+			tails[alternative.id] = tail
+		}
+	}
+
+	return tails
+}
+
+// symbolCounts returns the numbers of letters a symbol might lay out, when there are count left
+func (e *grammarExpansion) symbolCounts(symbol grammarSymbol, count int) []int {
+	switch symbol.kind {
+	case symbolRule:
+		counts := []int{}
+
+		for _, extra := range e.lengths[symbol.text] {
+			if extra <= count {
+				counts = append(counts, extra)
+			}
+		}
+
+		return counts
+	case symbolWord:
+		if count == 0 {
+			return nil
+		}
+
+		return []int{1}
+	default:
+		return []int{0}
+	}
+}
+
+// ruleFits returns true if the rule can lay out count letters from start
+func (e *grammarExpansion) ruleFits(rule string, start int, count int) bool {
+	key := expansionKey{alternative: -1 - e.ruleIDs[rule], start: start, count: count}
+
+	if fits, ok := e.fits[key]; ok {
+		return fits
+	}
+
+	e.fits[key] = false
+
+	for _, alternative := range e.grammar.rules[rule] {
+		if e.alternativeFits(alternative, 0, start, count) {
+			e.fits[key] = true
+
+			break
+		}
+	}
+
+	return e.fits[key]
+}
+
+// alternativeFits returns true if the symbols of an alternative from position on can lay out count letters from
+// start
+func (e *grammarExpansion) alternativeFits(alternative grammarAlternative, position int, start int, count int) bool {
+	if e.avoided(alternative) {
+		return false
+	}
+
+	if position == len(alternative.symbols) {
+		return count == 0
+	}
+
+	if !e.tails[alternative.id][position][count] {
+		return false
+	}
+
+	key := expansionKey{alternative: alternative.id, position: position, start: start, count: count}
+
+	if fits, ok := e.fits[key]; ok {
+		return fits
+	}
+
+	e.fits[key] = false
+	symbol := alternative.symbols[position]
+
+	for _, extra := range e.symbolCounts(symbol, count) {
+		if e.tails[alternative.id][position+1][count-extra] && e.symbolFits(symbol, start, extra) &&
+			e.alternativeFits(alternative, position+1, start+extra, count-extra) {
+			e.fits[key] = true
+
+			break
+		}
+	}
+
+	return e.fits[key]
+}
+
+// avoided returns true if the alternative is a last resort that's being left out
+func (e *grammarExpansion) avoided(alternative grammarAlternative) bool {
+	return e.avoidLastResorts && alternative.weight <= lastResortWeight
+}
+
+// symbolFits returns true if the symbol can lay out count letters from start
+func (e *grammarExpansion) symbolFits(symbol grammarSymbol, start int, count int) bool {
+	switch symbol.kind {
+	case symbolRule:
+		return e.ruleFits(symbol.text, start, count)
+	case symbolWord:
+		return count == 1 && e.allowed(start, symbol.text)
+	default:
+		return count == 0
+	}
+}
+
+// ruleInside returns how likely the rule is to lay out count letters from start
+//
+// A layout is as likely as the weights of the alternatives in it, over the weights of the other alternatives of
+// their rules, multiplied together. So picking a layout by how likely it is picks each alternative by its weight.
+func (e *grammarExpansion) ruleInside(rule string, start int, count int) float64 {
+	key := expansionKey{alternative: -1 - e.ruleIDs[rule], start: start, count: count}
+
+	if inside, ok := e.insides[key]; ok {
+		return inside
+	}
+
+	e.insides[key] = 0
+	total := 0.0
+	inside := 0.0
+
+	for _, alternative := range e.grammar.rules[rule] {
+		total += alternative.weight
+	}
+
+	for _, alternative := range e.grammar.rules[rule] {
+		inside += alternative.weight / total * e.alternativeInside(alternative, 0, start, count)
+	}
+
+	e.insides[key] = inside
+
+	return inside
+}
+
+// alternativeInside returns how likely the symbols of an alternative from position on are to lay out count letters
+// from start
+func (e *grammarExpansion) alternativeInside(
+	alternative grammarAlternative,
+	position int,
+	start int,
+	count int,
+) float64 {
+	if e.avoided(alternative) {
+		return 0
+	}
+
+	if position == len(alternative.symbols) {
+		if count == 0 {
+			return 1
+		}
+
+		return 0
+	}
+
+	if !e.tails[alternative.id][position][count] {
+		return 0
+	}
+
+	key := expansionKey{alternative: alternative.id, position: position, start: start, count: count}
+
+	if inside, ok := e.insides[key]; ok {
+		return inside
+	}
+
+	e.insides[key] = 0
+	symbol := alternative.symbols[position]
+	inside := 0.0
+
+	for _, extra := range e.symbolCounts(symbol, count) {
+		if e.tails[alternative.id][position+1][count-extra] && e.symbolFits(symbol, start, extra) &&
+			e.alternativeFits(alternative, position+1, start+extra, count-extra) {
+			inside += e.symbolInside(symbol, start, extra) *
+				e.alternativeInside(alternative, position+1, start+extra, count-extra)
+		}
+	}
+
+	e.insides[key] = inside
+
+	return inside
+}
+
+// symbolInside returns how likely the symbol is to lay out count letters from start
+func (e *grammarExpansion) symbolInside(symbol grammarSymbol, start int, count int) float64 {
+	if symbol.kind == symbolRule {
+		return e.ruleInside(symbol.text, start, count)
+	}
+
+	return 1
+}
+
+// expandRule picks one of a rule's alternatives that lays out count letters from start, and expands it to words
+func (e *grammarExpansion) expandRule(rule string, start int, count int) []grammarSymbol {
+	candidates := []grammarAlternative{}
+	weights := []float64{}
+
+	for _, alternative := range e.grammar.rules[rule] {
+		if e.alternativeFits(alternative, 0, start, count) {
+			candidates = append(candidates, alternative)
+			weights = append(weights, alternative.weight*e.alternativeInside(alternative, 0, start, count))
+		}
+	}
+
+	return e.expandAlternative(candidates[e.pick(weights)], 0, start, count)
+}
+
+// expandAlternative expands the symbols of an alternative from position on to lay out count letters from start
+func (e *grammarExpansion) expandAlternative(
+	alternative grammarAlternative,
+	position int,
+	start int,
+	count int,
+) []grammarSymbol {
+	if position == len(alternative.symbols) {
+		return nil
+	}
+
+	symbol := alternative.symbols[position]
+	counts := []int{}
+	weights := []float64{}
+
+	for _, extra := range e.symbolCounts(symbol, count) {
+		if e.tails[alternative.id][position+1][count-extra] && e.symbolFits(symbol, start, extra) &&
+			e.alternativeFits(alternative, position+1, start+extra, count-extra) {
+			counts = append(counts, extra)
+			weights = append(weights, e.symbolInside(symbol, start, extra)*
+				e.alternativeInside(alternative, position+1, start+extra, count-extra))
+		}
+	}
+
+	extra := counts[e.pick(weights)]
+	expanded := []grammarSymbol{symbol}
+
+	if symbol.kind == symbolRule {
+		expanded = e.expandRule(symbol.text, start, extra)
+
+		for i := range expanded {
+			expanded[i].title = expanded[i].title || symbol.title
+		}
+	}
+
+	return append(expanded, e.expandAlternative(alternative, position+1, start+extra, count-extra)...)
+}
+
+// pick returns the index of one of the weights, more likely the bigger it is, without using random if there's one
+//
+// Very long layouts can be so unlikely that their weights round down to nothing, then every one is as likely.
+func (e *grammarExpansion) pick(weights []float64) int {
+	if len(weights) == 1 {
+		return 0
+	}
+
+	total := 0.0
+
+	for _, weight := range weights {
+		total += weight
+	}
+
+	target := float64(e.random.Intn(pickResolution)) / pickResolution
+
+	for i, weight := range weights {
+		share := 1 / float64(len(weights))
+
+		if total > 0 {
+			share = weight / total
+		}
+
+		if target < share {
+			return i
+		}
+
+		target -= share
+	}
+
+	return len(weights) - 1
+}
+
+// grammarSlots turns expanded symbols into the template's slots, giving each word the next letter
+func grammarSlots(symbols []grammarSymbol, letters []string) []Slot {
+	slots := []Slot{}
+	sentence := 0
+	index := 0
+
+	for _, symbol := range symbols {
+		switch symbol.kind {
+		case symbolWord:
+			slots = append(slots, Slot{
+				Letter:       letters[index],
+				Index:        index,
+				PartOfSpeech: symbol.text,
+				Sentence:     sentence,
+				Title:        symbol.title,
+			})
+			index++
+		case symbolLiteral:
+			literal := symbol.text
+
+			if symbol.title {
+				literal = capitalise(literal)
+			}
+
+			slots = append(slots, Slot{Literal: literal, Index: -1, Sentence: sentence})
+		case symbolLineBreak:
+			if len(slots) > 0 {
+				slots[len(slots)-1].EndsLine = true
+			}
+		case symbolPunctuation:
+			if len(slots) == 0 {
+				continue
+			}
+
+			// A full stop is what ends a sentence anyway, so only other punctuation is kept
+			last := &slots[len(slots)-1]
+
+			if symbol.text != "." {
+				last.Ending += symbol.text
+			}
+
+			if strings.ContainsAny(symbol.text, ".?!") {
+				last.EndsSentence = true
+				sentence++
+			}
+		}
+	}
+
+	return slots
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// testGrammar is questions and statements, with the words in between that don't stand for letters
+const testGrammar = `
+# Questions are less likely than statements
+mnemonic  = statement | statement mnemonic
+statement = "the" adj noun verb .
+          | noun verb "the" noun ? [0.5]
+`
+
+var _ = Describe("Grammar", func() {
+	It("Lays the letters out the same way as NewTemplate by default", func() {
+		letters := strings.Split("abcdefghij", "")
+		actual, err := DefaultGrammar().Expand(letters, NewSeededRandomSource(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.GetTemplate()).To(Equal(NewTemplate(letters).GetTemplate()))
+	})
+	It("Leaves literal text out of the letters and puts punctuation after the word", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun verb "the" noun ?`))
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := grammar.Expand([]string{"a", "b", "c"}, NewSeededRandomSource(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.GetTemplate()).To(Equal(
+			`{{ .Param1 | noun }} {{ .Param2 | verb }} {{ "the" }} {{ .Param3 | noun }}?`,
+		))
+		Expect(actual.GetSlots()[2]).To(Equal(Slot{Literal: "the", Index: -1}))
+		Expect(actual.GetUsedFunctions()).To(Equal([]string{"noun", "verb"}))
+		Expect(actual.GetParameters()).To(HaveLen(3))
+	})
+	It("Capitalises and breaks lines", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = ^noun : / ^"the" verb`))
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := grammar.Expand([]string{"a", "b"}, NewSeededRandomSource(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.GetTemplate()).To(Equal("{{ .Param1 | noun | title }}:\n{{ \"The\" }} {{ .Param2 | verb }}"))
+	})
+	It("Picks between layouts by weight, the same way for the same seed", func() {
+		grammar, err := ReadGrammar(strings.NewReader(testGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		layouts := make(map[string]int)
+		letters := strings.Split("abcdef", "")
+
+		for seed := int64(0); seed < 100; seed++ {
+			actual, err := grammar.Expand(letters, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			again, _ := grammar.Expand(letters, NewSeededRandomSource(seed))
+			Expect(again.GetTemplate()).To(Equal(actual.GetTemplate()))
+
+			layouts[actual.GetTemplate()]++
+		}
+
+		Expect(layouts).To(HaveLen(4))
+		Expect(layouts[`{{ "the" }} {{ .Param1 | adj }} {{ .Param2 | noun }} {{ .Param3 | verb }}. `+
+			`{{ "the" }} {{ .Param4 | adj }} {{ .Param5 | noun }} {{ .Param6 | verb }}.`]).To(BeNumerically(">", 25))
+	})
+	It("Counts sentences by their punctuation", func() {
+		grammar, err := ReadGrammar(strings.NewReader(testGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := grammar.Expand(strings.Split("abcdef", ""), NewSeededRandomSource(1))
+		Expect(err).NotTo(HaveOccurred())
+
+		slots := actual.GetSlots()
+		Expect(slots[len(slots)-1].Sentence).To(Equal(1))
+		Expect(slots[len(slots)-1].EndsSentence).To(BeTrue())
+	})
+	It("Says when there's no layout for that many letters", func() {
+		grammar, err := ReadGrammar(strings.NewReader(testGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = grammar.Expand(strings.Split("ab", ""), NewSeededRandomSource(42))
+
+		Expect(err).To(Equal(&NoExpansionError{Letters: 2}))
+	})
+	It("Rejects grammars it can't read", func() {
+		for grammar, message := range map[string]string{
+			"":                           "the grammar has no rules",
+			"noun verb":                  "line 1: expected a rule name then =",
+			"| noun":                     "line 1: expected a rule name then =",
+			"a = noun [0]":               "line 1: weight [0] isn't a number above 0",
+			"a = noun [2] verb":          `line 1: "verb" after the weight`,
+			"a = noun\nb = \"the noun":   `line 2: '"' isn't closed`,
+			"a = noun @":                 `line 1: unexpected '@'`,
+			"a = noun\n\n  | 2nd":        `line 3: unexpected "2nd"`,
+			"a = noun\n# comment\nb = =": `line 3: unexpected "="`,
+			"a = noun ^":                 `line 1: ^ before "|"`,
+			"a = ^ .":                    `line 1: ^ before "."`,
+		} {
+			_, err := ReadGrammar(strings.NewReader(grammar))
+
+			Expect(err).To(MatchError(message), grammar)
+		}
+	})
+	It("Loads grammars from files", func() {
+		_, err := LoadGrammar("/does/not/exist")
+
+		Expect(err).To(HaveOccurred())
+	})
+})
+
+func ExampleGrammar_Expand() {
+	grammar, err := ReadGrammar(strings.NewReader(`mnemonic = "the" adj noun verb !`))
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	template, err := grammar.Expand([]string{"d", "e", "m"}, NewSeededRandomSource(42))
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	result, err := NewTemplateParserV2(
+		NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj")),
+		NewWordGeneratorAdapter(NewStaticWordGenerator("eggs", "noun")),
+		NewWordGeneratorAdapter(NewStaticWordGenerator("move", "verb")),
+	).Generate(context.Background(), template, nil)
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	fmt.Println(result)
+	// Output: the dancing eggs move!
+}
@@ -0,0 +1,254 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"sort"
+	"strings"
+)
+
+// weightScale is how many units of chance a word with a weight of 1 gets when picking weighted words
+const weightScale = 1000
+
+// maxWeightUnits is the most units of chance a word can get, so adding up a pool's weights can't overflow
+const maxWeightUnits = 1 << 30
+
+// LexiconEntry is a word in a lexicon, and what we know about it
+type LexiconEntry struct {
+	Lemma        string
+	PartOfSpeech string
+	SynsetID     string
+	Gloss        string
+	Source       string
+	Tags         []string
+	// Weight is how likely the word is to be picked compared to the others, zero counts as 1
+	Weight float64
+	// Frequency is how often the word is used, zero if we don't know
+	Frequency int
+	// Domain is the category of the word, like WordNet's lexicographer files noun.animal or verb.motion
+	Domain string
+	// Related are the ids of the synsets WordNet links the word's synset to, like its hypernyms, hyponyms and parts,
+	// which themes follow to find words near the topic
+	Related []string
+}
+
+// Word returns the entry as a word that can go in a mnemonic
+func (e LexiconEntry) Word() Word {
+	return Word{
+		Lemma:        e.Lemma,
+		PartOfSpeech: e.PartOfSpeech,
+		SynsetID:     e.SynsetID,
+		Gloss:        e.Gloss,
+		Source:       e.Source,
+		Tags:         e.Tags,
+	}
+}
+
+// effectiveWeight returns the entry's weight, with zero counting as 1
+func (e LexiconEntry) effectiveWeight() float64 {
+	if e.Weight <= 0 {
+		return 1
+	}
+
+	return e.Weight
+}
+
+// weightUnits returns the entry's chance of being picked as a whole number, between 1 and maxWeightUnits
+func (e LexiconEntry) weightUnits() int {
+	units := e.effectiveWeight()*weightScale + 0.5
+
+	if units >= maxWeightUnits {
+		return maxWeightUnits
+	}
+
+	if units < 1 {
+		return 1
+	}
+
+	return int(units)
+}
+
+// Lexicon is a set of words grouped by part of speech and then by the letter they begin with, in lower case
+type Lexicon struct {
+	pools map[string]map[string][]LexiconEntry
+	// cumulative is the running total of weightUnits for each pool, in the same order as the pool
+	cumulative  map[string]map[string][]int
+	weighted    bool
+	frequencies bool
+	size        int
+}
+
+// NewLexicon returns a lexicon with the given entries in it
+//
+// Could be used like
+//   lexicon := mnemonic.NewLexicon(
+//     mnemonic.LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+//     mnemonic.LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+//   )
+func NewLexicon(entries ...LexiconEntry) *Lexicon {
+	lexicon := &Lexicon{
+		pools:      make(map[string]map[string][]LexiconEntry),
+		cumulative: make(map[string]map[string][]int),
+	}
+	lexicon.Add(entries...)
+
+	return lexicon
+}
+
+// Add puts more entries in the lexicon
+func (l *Lexicon) Add(entries ...LexiconEntry) {
+	for _, entry := range entries {
+		if entry.Lemma == "" {
+			continue
+		}
+
+		// Pooled by the lower case letter, as that's what's asked for, but the word keeps its case
+		letter := strings.ToLower(getCharAt(entry.Lemma, 0))
+
+		if l.pools[entry.PartOfSpeech] == nil {
+			l.pools[entry.PartOfSpeech] = make(map[string][]LexiconEntry)
+			l.cumulative[entry.PartOfSpeech] = make(map[string][]int)
+		}
+
+		l.pools[entry.PartOfSpeech][letter] = append(l.pools[entry.PartOfSpeech][letter], entry)
+		l.cumulative[entry.PartOfSpeech][letter] = appendWeight(l.cumulative[entry.PartOfSpeech][letter], entry)
+		l.weighted = l.weighted || (entry.Weight != 0 && entry.Weight != 1)
+		l.frequencies = l.frequencies || entry.Frequency > 0
+		l.size++
+	}
+}
+
+// appendWeight adds an entry's weight to the running totals for its pool
+func appendWeight(cumulative []int, entry LexiconEntry) []int {
+	total := 0
+
+	if len(cumulative) > 0 {
+		total = cumulative[len(cumulative)-1]
+	}
+
+	return append(cumulative, total+entry.weightUnits())
+}
+
+// Pool returns the entries for a part of speech that begin with the letter
+//
+// The returned slice belongs to the lexicon and mustn't be changed
+func (l *Lexicon) Pool(partOfSpeech string, letter string) []LexiconEntry {
+	return l.pools[partOfSpeech][letter]
+}
+
+// Pick returns a random entry for a part of speech that begins with the letter, favouring the heavier ones
+//
+// It returns false if there are no entries. When no entry has a weight every one is equally likely.
+//
+// Could be used like
+//   entry, ok := lexicon.Pick("noun", "a", mnemonic.NewSeededRandomSource(42))
+func (l *Lexicon) Pick(partOfSpeech string, letter string, random RandomSource) (LexiconEntry, bool) {
+	entries := l.pools[partOfSpeech][letter]
+
+	if len(entries) == 0 {
+		return LexiconEntry{}, false
+	}
+
+	if !l.weighted {
+		return entries[random.Intn(len(entries))], true
+	}
+
+	cumulative := l.cumulative[partOfSpeech][letter]
+	chosen := random.Intn(cumulative[len(cumulative)-1])
+
+	return entries[sort.SearchInts(cumulative, chosen+1)], true
+}
+
+// PartsOfSpeech returns the parts of speech that have words in the lexicon
+//
+// The ones templates use come first in order of preference, then any others sorted by name
+func (l *Lexicon) PartsOfSpeech() []string {
+	partsOfSpeech := []string{}
+	others := []string{}
+	preferred := make(map[string]bool)
+
+	for _, partOfSpeech := range availableFunctions() {
+		preferred[partOfSpeech] = true
+
+		if l.pools[partOfSpeech] != nil {
+			partsOfSpeech = append(partsOfSpeech, partOfSpeech)
+		}
+	}
+
+	for partOfSpeech := range l.pools {
+		if !preferred[partOfSpeech] {
+			others = append(others, partOfSpeech)
+		}
+	}
+
+	sort.Strings(others)
+
+	return append(partsOfSpeech, others...)
+}
+
+// Entries returns every entry in the lexicon, grouped by part of speech and then letter
+func (l *Lexicon) Entries() []LexiconEntry {
+	entries := make([]LexiconEntry, 0, l.size)
+
+	for _, partOfSpeech := range l.PartsOfSpeech() {
+		letters := []string{}
+
+		for letter := range l.pools[partOfSpeech] {
+			letters = append(letters, letter)
+		}
+
+		sort.Strings(letters)
+
+		for _, letter := range letters {
+			entries = append(entries, l.pools[partOfSpeech][letter]...)
+		}
+	}
+
+	return entries
+}
+
+// sortPools puts the entries in every pool in a fixed order, so a seed always picks the same word
+func (l *Lexicon) sortPools() {
+	for partOfSpeech, letters := range l.pools {
+		for letter, entries := range letters {
+			sort.Slice(entries, func(i, j int) bool {
+				if entries[i].Lemma != entries[j].Lemma {
+					return entries[i].Lemma < entries[j].Lemma
+				}
+
+				return entries[i].Gloss < entries[j].Gloss
+			})
+
+			cumulative := make([]int, 0, len(entries))
+
+			for _, entry := range entries {
+				cumulative = appendWeight(cumulative, entry)
+			}
+
+			l.cumulative[partOfSpeech][letter] = cumulative
+		}
+	}
+}
+
+// Len returns the number of entries in the lexicon
+func (l *Lexicon) Len() int {
+	return l.size
+}
+
+// wordKey returns what entries are matched on when comparing lexicons, the word ignoring case and its part of speech
+func wordKey(lemma string, partOfSpeech string) string {
+	return strings.ToLower(lemma) + "\x00" + partOfSpeech
+}
@@ -0,0 +1,112 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+//go:generate go run -tags noembed ../cmd/mnemonic-embed/main.go -words default_lexicon.tsv -o lexicon_embedded_data.go
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// embeddedSource is the source given to words from the embedded lexicon
+const embeddedSource = "embedded"
+
+// ErrNoEmbeddedLexicon is returned by EmbeddedLexicon when the program was built with the noembed tag
+var ErrNoEmbeddedLexicon = errors.New("built without the embedded lexicon")
+
+// embeddedLexicon is the embedded lexicon, only decompressed the first time it's needed
+var embeddedLexicon struct {
+	sync.Once
+	lexicon *Lexicon
+	err     error
+}
+
+// EmbeddedLexicon returns the small lexicon of common words that's built into the program
+//
+// It's there so mnemonics can be made without a dictionary, build with the noembed tag to leave it out. The words
+// come from default_lexicon.tsv, a hand made list with the WordNet domain of each word but no synsets or glosses.
+//
+// Could be used like
+//   lexicon, err := mnemonic.EmbeddedLexicon()
+func EmbeddedLexicon() (*Lexicon, error) {
+	embeddedLexicon.Do(func() {
+		if embeddedLexiconData == "" {
+			embeddedLexicon.err = ErrNoEmbeddedLexicon
+			return
+		}
+
+		reader, err := gzip.NewReader(strings.NewReader(embeddedLexiconData))
+
+		if err != nil {
+			embeddedLexicon.err = err
+			return
+		}
+
+		entries, err := readTaggedWords(reader, embeddedSource)
+
+		if err != nil {
+			embeddedLexicon.err = err
+			return
+		}
+
+		embeddedLexicon.lexicon = NewLexicon(entries...)
+	})
+
+	return embeddedLexicon.lexicon, embeddedLexicon.err
+}
+
+// readTaggedWords reads lines of a part of speech and a word, then optionally the word's domain, synset id and gloss,
+// separated by tabs
+func readTaggedWords(reader io.Reader, source string) ([]LexiconEntry, error) {
+	entries := []LexiconEntry{}
+	scanner := bufio.NewScanner(reader)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+
+		fields := strings.Split(scanner.Text(), "\t")
+
+		if len(fields) < 2 || len(fields) > 5 {
+			return nil, fmt.Errorf(
+				"line %d: expected a part of speech, a word, and optionally a domain, synset id and gloss separated by tabs",
+				line,
+			)
+		}
+
+		fields = append(fields, "", "", "")
+		entries = append(entries, LexiconEntry{
+			Lemma:        fields[1],
+			PartOfSpeech: fields[0],
+			Domain:       fields[2],
+			SynsetID:     fields[3],
+			Gloss:        fields[4],
+			Source:       source,
+		})
+	}
+
+	return entries, scanner.Err()
+}
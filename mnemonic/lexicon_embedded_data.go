@@ -0,0 +1,61 @@
+// Code generated by mnemonic-embed; DO NOT EDIT.
+
+//go:build !noembed
+// +build !noembed
+
+package mnemonic
+
+// embeddedLexiconData is 514 words, gzipped lines of a part of speech, a word, and its domain, synset id and gloss when known
+const embeddedLexiconData = "" +
+	"\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\x74\x5a\xdb\x9e\xbb\xac\x0e\xbd\x9e\xfd\x30\xdf\x3b\x45\x8c\x98\x16\x88\x13\x40\xff\xed\xd3\xef\x9f\xb6\x63\x25\xa4\x77\x33\x0b\xcc\x61\x91\x03\x50\x60" +
+	"\xbc\xfd\xc0\x10\xf0\x07\xc6\xdb\x7f\x10\xc2\xff\x0e\xc0\x15\x5a\x15\x94\x1c\x61\x2a\x0a\xf3\xf2\x68\x91\x0d\xee\xed\x77\x03\xf9\xf6\xff\x50\xd5\x04\x0e\x63\x0b\x08\x28\xdd\x83\x90\x9f\x5b\xd5" +
+	"\x43\xcd\xad\x66\x07\x21\xb6\xc0\x8c\x28\x53\x0d\x2d\x18\x70\x45\x69\x21\xad\xdf\xb1\x16\x5d\x85\xb8\xe6\x06\x1b\x41\xee\x2d\x80\xb8\xb4\x00\x3d\x9f\xad\x9c\x51\x10\xa2\x82\x6a\x2e\x2d\x82\xe0" +
+	"\x95\x81\x08\x12\xf4\x1c\x65\x21\x06\xf4\xa0\x16\x07\xe3\xa2\x44\x4f\x90\x9c\x46\x72\xfb\xd1\x44\x28\xae\x25\x7f\x0a\x75\x9a\xd4\x67\x42\x98\x46\x65\xd4\x54\x53\x6a\x11\x8f\xa9\xa8\xc0\xf2\xa4" +
+	"\xcd\xf4\x01\x5a\xfa\x3d\x87\x11\x93\x82\x58\xcd\x11\x54\x53\x66\x58\x96\x56\xfb\x8c\xb0\x2a\x84\x13\x2a\x7f\xe7\xea\x51\x01\x5d\x4c\x93\x22\x8d\x46\xe5\x14\xc5\x85\xa5\x68\xc7\x6e\x1c\x14\x43" +
+	"\x37\x7e\xe8\x78\xbc\x55\x2d\xfd\xae\x3d\xbb\x53\x6a\x9d\x0f\x20\xca\xe8\x00\x2a\xd2\x02\x15\xcd\x7c\xa0\x15\x95\x3d\x81\x53\x0f\x55\xa5\xac\xba\x7b\x3b\x25\x82\x27\xd7\x22\x28\x8a\xb2\xb8\xe7" +
+	"\xab\x82\xea\x38\xb6\x48\x02\x11\xde\x5a\x08\xa1\x28\x40\x56\x9d\x7a\x09\xd5\x47\xac\xcb\x57\x62\x52\x09\xc2\x63\xeb\x96\xce\x79\x16\x48\x8a\xd4\x05\x4a\x57\xf0\x16\x94\x09\x9d\xc2\x28\xb5\x95" +
+	"\x60\xe1\x40\x45\xc9\x12\xcd\xeb\x52\x65\x51\x56\xff\x56\x72\x77\x8d\x60\xab\x4c\x60\xa1\x56\x90\xa0\xfa\x9f\xdc\xdc\x02\x5c\x55\x00\x09\x3f\xa0\x0d\xc3\xac\x72\x30\xcf\xa4\x72\x39\x93\x8e\xe6" +
+	"\x1c\x10\x55\xca\xe5\x08\x21\x68\x44\x5a\x0f\x32\x4f\x0a\x28\xc2\xa9\xed\x11\xb9\x2b\x25\x79\x43\xc5\x44\xd1\xaa\x0a\xc4\x96\xcf\x42\x2a\xe0\x8a\x76\xaa\x70\xf5\x2d\x59\xd5\x2b\x2f\xeb\x92\x95" +
+	"\xe2\x9a\x51\x67\xf1\xaa\xcb\xe8\x8a\x61\x45\x95\x00\x2b\x71\x50\xa2\x36\x90\xb6\x67\x6d\xa4\xe2\x72\xa3\xdc\x3a\xb5\x31\xeb\xea\xf8\xc0\x10\x54\x1e\x3d\xb8\x2a\x4a\x9f\xa0\x7c\x7f\x22\x84\x6b" +
+	"\x66\x25\xae\xe9\x07\x1c\x4b\xfa\xd9\xff\xfc\x6f\x09\x90\xca\x1b\x4d\x6e\x66\x79\xc1\x20\x85\x26\x70\xe7\x88\xc7\xf0\x9e\x8f\x92\x39\xfd\xc1\xe5\x3d\x3b\x51\x84\x3f\xd9\xcb\x1e\xf0\x07\x3c\x31" +
+	"\x8f\x6f\x50\xdc\x6c\x0a\x3e\x4a\x83\x39\x50\x28\x17\x43\x65\x29\xe4\xcc\x0f\x6a\xa9\xf1\xed\x53\xa1\x88\x2f\x74\x80\x71\x6f\xb3\x9d\x91\x03\x24\x48\xa0\xad\x1c\x20\xdf\xb1\x58\xd2\x07\x84\x3f" +
+	"\x07\x78\xb8\xe1\x05\xb6\x84\x63\x08\xa6\x10\x72\x0f\x17\xd0\x1e\x92\xd1\x10\x14\x20\x7d\x33\x88\xe1\x0b\xce\x77\x1b\x3f\x3a\x85\x35\x22\x34\x7a\x7b\xa4\xba\x6f\xda\x6b\x29\x28\x9a\x3e\x07\x03" +
+	"\x25\x6b\xba\x03\x57\x6a\xee\xe2\xcd\x41\xc4\xd0\x7b\xed\x20\x8d\xb6\xa9\x0e\x12\x7f\x19\x10\xe1\xd2\xdb\x93\xcb\x37\x41\x46\xe0\xba\xc0\xce\xe4\xce\x1d\xdd\xf2\x65\xfd\x8c\x89\x23\xa6\xbf\x70" +
+	"\x74\xec\x38\xd5\x5e\x33\x47\x2c\x7d\xb8\x38\xe6\x3b\x61\x3f\xb9\x14\xb0\x57\xc0\x09\x0c\x86\xa1\xc2\x9b\xc9\xf3\x08\x7b\x3f\xd4\x34\x8f\x90\x1c\x4a\x9f\x4a\x23\x66\x14\xc3\xca\x91\x20\x72\x7a" +
+	"\x3b\x9c\xeb\x90\xcb\x2e\xe0\x6f\x2c\x71\x86\x6a\x04\xfd\xc8\xae\xb0\xa5\x85\xbd\x35\x39\x2c\x33\x25\x6b\x20\xdd\xf1\x61\xe1\x76\x61\x1a\x05\x3c\x27\x43\xab\xd4\x68\xce\xaf\xee\xde\x4b\x47\xf0" +
+	"\x01\x2d\x58\x8a\x91\xf4\xe8\x66\xb6\xa3\x01\xbd\xd7\x8b\x8b\x61\xf8\x2b\x70\x03\x8f\x8f\x3f\x10\x97\xd9\x2c\x9e\x98\x3c\x25\x33\x14\x30\xad\x18\x78\xb1\xc7\x04\x32\x9a\xfc\xe0\x8a\x89\x92\xd7" +
+	"\x65\x71\x82\xe0\xd8\xa0\x7f\x02\x89\x56\xa8\x4c\x08\x65\xb6\xea\xe8\x84\x46\x1f\x99\x08\xc3\x3b\x7c\x02\x3b\x28\x74\x8a\x21\x79\xdb\xbf\xdb\x75\xce\xce\xb3\x21\x38\x80\xb7\x1c\x9a\x42\x2d\x26" +
+	"\x07\x13\x0b\x66\x23\x9a\x27\xae\xa9\x80\x5d\x97\x26\xfe\x67\x68\x16\x2b\x62\x3d\x04\xf8\xf7\xe8\xc5\x7b\x90\x11\x93\xe5\xac\x07\x09\xe4\x74\x3c\x78\xb0\xcd\xf7\x33\x5b\xed\xee\x75\x8e\x32\x60" +
+	"\x81\x69\x32\x42\xd6\x07\x70\x84\x62\xd8\xc9\x56\xc9\xf3\xcc\xd9\x92\x22\xb0\x74\x65\xca\x57\x2a\x60\x06\xd9\x0c\x31\xe2\x97\x11\x19\x58\x2c\x76\x66\x90\xc5\xfe\xa2\xd8\xf0\x66\xe4\xed\xbc\x67" +
+	"\xa8\x4e\xae\x19\x47\x8f\xb3\xb5\x86\x33\x86\x68\xf7\xb3\x99\x42\xe8\x39\xdb\xcf\x91\x0f\x4d\xc3\xcc\x62\x51\x36\x73\xcd\xe6\xc2\x92\xc3\x01\xc5\xf7\xd2\xc9\x07\x66\xf3\x8b\x94\xf7\xb3\x47\xa7" +
+	"\x82\xd2\x9e\x34\x56\x8d\x25\xe1\x64\x96\x6b\xca\x01\xd2\x68\xe8\x5e\xfb\x36\x71\x83\x6f\xdd\xfe\x06\xbe\x5a\xfb\x9c\x1b\x44\xcd\xce\xcd\x8e\x90\x1b\xee\xa7\x0a\x3d\x17\x37\x34\xf7\x49\x37\xf2" +
+	"\x19\xcc\x5d\xe1\xad\x9e\x5b\x95\xab\xff\xfb\xe9\xba\x8b\xd7\x5b\x4d\x67\x55\xbf\xfa\x7e\x87\xe4\x41\x98\x7b\x7f\xee\xf8\x6d\x8f\xf4\xe9\x49\x2d\x7c\x96\xd6\xab\x35\x77\x2a\x6e\xc6\x64\x7f\x60" +
+	"\x67\xff\x9d\x4a\x41\xa3\x1e\xdf\xd3\x71\x21\xd6\xab\x60\x08\xd0\x4f\x0f\x30\x8e\x76\x22\x86\xfd\xba\xae\xa3\x22\x40\x5c\xec\xd9\xa9\x9c\x85\x5d\x0d\x61\xe4\xa4\xa9\x0e\xc8\x0b\x58\x1b\xd8\x40" +
+	"\x83\x80\x98\xdc\x85\xdd\xb3\xaf\x59\x13\xc8\x6a\x4f\x81\x9e\xb6\x1a\x1e\x72\xb1\xda\x53\x04\x9f\xec\x98\x8e\x90\x3c\x6b\x3f\x22\x98\x74\x44\x84\x91\xb7\x9e\xbe\x88\xa1\xe7\x22\xa2\x44\xa0\xb1" +
+	"\x5f\xb3\x88\x05\x59\xec\x8d\x43\x24\x11\x7b\x83\x13\xbf\x6c\x88\x22\x73\x32\x2c\x6a\x9a\x9d\x1a\xb1\xca\x56\xac\x79\x16\xe6\xd8\x55\x83\x04\x64\xe6\x66\x82\xe5\x6e\x37\xd3\x84\xee\x1e\xc0\xa1" +
+	"\x3d\x86\x5f\xb6\xf4\xe9\x6c\xda\x0a\xff\x04\xfe\x67\xdb\x92\x98\xc7\xfe\x6c\x99\xea\x59\x91\xaf\x7c\x1b\xdb\x72\x86\x7b\xe7\x28\x3b\x04\x83\xaf\x7d\x37\xbb\xd4\xdc\x33\xc6\x81\xd6\xce\x04\x4e" +
+	"\xd4\x47\xc2\xfb\xae\xa9\x43\xdd\x7c\x06\x71\xdb\x13\x39\x97\xe3\x56\xa7\xd7\x59\xcc\xe8\xe6\xcd\x38\x3d\xf1\xc3\x4e\x85\x05\x28\x15\x6b\x6f\xb7\x40\x1a\xc1\x9a\xff\x39\x52\x35\xf8\xe7\x24\xfc" +
+	"\x71\x6a\x39\xcf\xc1\x57\x6c\x18\xac\xfa\xbb\x60\x72\x76\x6c\x2d\x98\x7c\xb5\x4e\x05\x0b\x41\x32\x5b\xe5\x42\xc7\x95\x88\x39\x22\xe7\x4e\xab\xf1\x36\x40\xb2\x8e\x66\x4b\x8d\x9f\xc0\xbe\x38\x51" +
+	"\xf7\xeb\xe6\xce\xa0\xdf\xfa\x49\x8f\x16\x96\xf2\x34\x3b\xf1\x6f\x45\x34\xce\x29\xbf\x15\x73\x39\x63\xc7\x71\x8c\x35\xd1\x35\x26\x7e\x2b\x05\x33\x41\x04\x86\x81\x8c\xf5\x11\x70\x8e\xad\xe2\x29" +
+	"\x30\x12\xdb\x92\x28\xd9\x65\x69\x1f\x39\x8f\x2f\xfd\xe0\x6a\xb5\x2c\xa1\xd5\xda\x7f\x0a\x0f\x6c\xfb\xc1\xdf\xb6\x1d\x72\xee\x4d\x2f\xe9\x2a\x75\x78\x98\x04\x67\xa0\x60\xed\x8d\x32\x84\x68\xd1" +
+	"\x91\xe1\xcb\x11\x37\x3b\x90\xc9\xb2\x27\xcf\xfb\x6f\x41\xbd\xa0\x79\xff\x45\xc8\x80\xc9\x6c\x26\x39\x99\xa1\x93\xd3\x37\x9e\xf3\x42\xa3\x95\xd0\xf9\xb7\x92\x88\x75\x81\x92\x0b\x18\x2b\x90\xab" +
+	"\x51\xe6\xf2\x06\x06\x37\x05\x06\xbb\x5a\x97\x3d\xf9\xad\x22\x52\x10\x16\x7b\x7d\x0b\x99\x17\x70\x85\x23\x94\xae\x01\x17\x96\xc2\x64\x35\xaa\xc2\x9b\xbd\xb3\x29\x02\x9f\x8b\x87\x6e\xc8\x6e\x55" +
+	"\x45\xb0\x8f\xac\x22\x35\x2e\x76\x24\x96\x1a\x68\xe9\x3f\xa8\x52\xac\x6b\x83\x1a\x07\xc1\x10\xc0\x92\x54\xd3\x79\x01\x78\xa5\x6f\x4f\xfa\xcf\x8d\x6c\x3b\xb0\xe2\xd9\xde\xae\x2b\xb7\x42\x08\xf8" +
+	"\xb0\x70\x7b\x47\xf5\xba\xaa\x36\x47\x28\x84\xf3\xfe\xa9\xed\x48\x2b\x71\xb0\x29\x5c\x39\xb8\xb3\x28\x37\xfa\x6b\x28\x55\x0c\x5a\xb6\xcf\x4d\x4d\x2b\x6a\x83\x20\x56\xa7\xdd\xe0\xec\x57\x2a\x45" +
+	"\xb7\x19\x2c\xe2\xb7\x19\xed\x03\xc5\x76\x69\x13\x97\x05\xdc\x28\x8d\x76\xf7\xd8\x2e\x1b\xcd\xeb\x72\x6c\x1c\xa6\x5e\xef\xbf\x47\xe0\x65\x3f\x2c\x5a\xa2\x1e\xe0\xe6\x62\x0f\x18\xc5\xe4\x71\xaa" +
+	"\xd5\x93\xed\xed\xf8\x83\x7d\x95\x6e\x9f\xf3\xc4\x41\x8c\x96\xfe\xc4\x65\x7e\x7c\xd9\x81\x3e\xbb\xb3\xe8\x8a\x32\xfc\xc0\x18\x49\xf0\x67\xff\xfb\x3f\x8c\x7c\x44\xc6\x6b\x40\xf6\x42\xff\x1a\x68" +
+	"\xf0\x7c\x7f\x81\x6d\x2f\x3b\xc6\x86\xfd\x10\xf2\x1a\x14\xbc\xe2\x5c\x93\x33\x64\x0d\x95\xc2\x68\x7d\xe0\x60\xff\x21\xf2\xad\x26\x95\xd3\x5a\x07\xc5\xcd\x16\x3e\xef\x49\xd1\xc9\x77\x81\xe2\x60" +
+	"\xc0\xfb\x15\xba\xa1\xf5\xb8\x47\xed\xa7\x8f\xe4\x0d\x95\xa3\xc9\xce\x28\xb0\x99\xa2\x05\x21\xfe\x49\xf1\x89\x3e\x23\x08\xe5\x94\x9e\x6b\x5c\x2e\x23\xe9\xc6\x0f\x63\x65\xf0\xdf\x12\x58\x0c\xe5" +
+	"\x13\x9e\xec\x34\x30\xa5\x37\xc9\x0b\x8a\xc3\x8b\x86\x29\x3c\x8c\xe9\x7c\xe4\x52\x87\x7b\x08\x81\x17\x0b\x3f\x6e\x0e\x7b\x86\x3c\xf9\xfd\x7c\xfe\x2d\x58\xfc\xa9\x45\x9b\xe5\xe5\x6f\xc4\xcd\x90" +
+	"\x3c\xbe\xd0\x99\x46\x34\xb4\xcc\x96\x4d\x73\xb5\x96\x6c\xae\xf1\xab\x35\x73\x4d\xe7\x3a\xec\x5d\xe2\xb2\x42\xb4\xff\x50\x9e\xd0\x5c\xbd\xd7\x95\x8d\xb5\xe2\x37\xf6\xbd\x5d\xb7\x7a\xa5\xe4\x62" +
+	"\xda\xad\x46\xc3\x8d\xfb\xbe\xf1\x38\xd0\x5c\xa0\xd0\xfa\x66\xe2\xbe\xff\xbc\xdc\xcb\xb8\x53\xce\x16\x9c\xc8\x34\xf0\x9e\xd8\x14\x13\x60\xff\x2d\xf5\x1b\x4f\x01\xc1\x30\x34\xd0\x54\x2c\x51\x94" +
+	"\x0b\x26\x7b\x91\xe3\xf1\x8b\x61\x27\x29\x62\x1a\x0d\x49\x91\xad\x6c\x4b\x7f\xb6\x1c\x97\x83\x2f\x88\x8e\x43\x89\x9d\x51\x89\xc7\x5e\x08\x0f\xf8\xce\x82\xcc\x8e\x20\xbc\xd1\x69\xfa\x0b\xea\x85" +
+	"\x73\xc6\x9c\x3f\x1f\x2c\x98\x0c\x13\x8f\xf3\x97\x45\xf4\xd1\x90\xac\x0f\x02\x3c\xbe\x84\xdc\xf2\xa5\x60\x2e\x35\x5b\xe5\xef\x3c\x64\x7c\x5b\xb5\xdf\x7a\x6c\xda\x3b\x79\x02\x96\x16\x41\x18\xcd" +
+	"\x68\x97\x9a\xfa\xd9\xfb\xbe\xdc\x40\xf7\x9b\x33\x83\x8c\x7c\x87\x62\xa8\x3c\xde\x20\xe8\xb5\xcc\x1b\xc5\x7e\x6a\x81\xf0\xbd\x03\x95\xf9\x53\x3b\xae\x0c\x15\x81\x15\x0d\x33\xcb\x46\x62\xc0\x35" +
+	"\x4d\x1c\xac\x38\xac\x29\xd8\x59\xb3\x42\xa2\x3c\xf7\x55\x6b\xa5\x4c\xa5\x0f\xaf\xed\x74\xe2\xaa\x76\x83\x34\x5a\xcb\xb4\xc1\xfa\xbd\x8e\x6e\x33\xe5\x05\xe5\xfb\xf8\xa5\x0c\x5f\x85\x3e\x60\x4b" +
+	"\x9a\xf1\x07\x86\xf0\x55\xd0\x93\xfc\x13\x8c\xa2\xf6\x64\x6e\x97\x09\xc6\xf5\x07\x42\xe4\xe3\x2d\xc5\xfa\x7e\xa8\xb0\x43\x1b\x3c\x72\x0b\x6d\xf7\x0d\xe4\xf5\x00\xed\x83\x0e\xd0\x21\x1c\x3a\xe8" +
+	"\x78\x4a\xa8\x40\x07\x21\x76\x90\xe0\x54\x83\x46\xdf\x6f\x0a\x15\x3c\x02\x69\x04\x71\x51\xd0\xf1\xb0\xaf\xc3\xb2\xfe\xf2\xfd\x94\x4f\xa1\xef\x87\x7a\x17\xe0\x78\xa8\xa7\x67\x51\x02\x6d\xdb\xf1" +
+	"\x08\x4f\x41\xa1\x63\xca\xef\x29\xdd\x3b\xb6\xbf\xaa\xd3\x06\xbe\x5e\xd1\x29\x90\x52\x2e\xbd\xd5\xaf\x07\x6f\x0a\xdc\xdf\xb3\x29\x28\xc0\x53\x6b\x09\x5c\xf5\xac\xd8\x59\xbd\x3f\x3c\xd3\x1f\xa6" +
+	"\xbf\xe7\x92\x0d\xa4\x2d\x4b\x4c\x1d\xf3\x3c\x15\x4c\x2d\xb2\x60\x52\x93\xde\xaf\xc2\x34\x7a\x3c\xf9\xd2\xa0\xf4\x3e\x1c\x6f\xbc\x7a\x0c\xb5\xc0\xe3\x9d\x57\x87\x89\x56\x91\x3b\x4a\x72\xe0\x4d" +
+	"\x43\x3c\x69\xf1\x05\xf7\x8a\xa1\x41\x1e\xa1\x45\xea\xb2\xa7\x59\x03\xad\x40\x9a\x92\x15\xa5\x05\xf6\x67\x4d\x6a\xce\x86\xa8\xdd\xde\xdf\x3a\x29\xe8\xd1\xaf\xdd\x03\xf7\x6b\x4b\x6d\xd8\xfb\xf1" +
+	"\xd2\x65\xee\xff\x07\x00\x17\xfc\xa0\x22\xc7\x2c\x00\x00"
@@ -0,0 +1,65 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("EmbeddedLexicon", func() {
+	It("Has words for the common letters in every part of speech", func() {
+		lexicon, err := EmbeddedLexicon()
+
+		if err == ErrNoEmbeddedLexicon {
+			Skip("built without the embedded lexicon")
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lexicon.PartsOfSpeech()).To(Equal([]string{"adj", "noun", "verb", "adv"}))
+
+		for _, letter := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "l", "m", "p", "r", "s", "t", "w"} {
+			Expect(lexicon.Pool("noun", letter)).NotTo(BeEmpty(), letter)
+		}
+
+		Expect(lexicon.Pool("noun", "a")[0].Source).To(Equal("embedded"))
+	})
+	It("Has a domain for every word", func() {
+		lexicon, err := EmbeddedLexicon()
+
+		if err == ErrNoEmbeddedLexicon {
+			Skip("built without the embedded lexicon")
+		}
+
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, entry := range lexicon.Entries() {
+			Expect(entry.Domain).To(HavePrefix(entry.PartOfSpeech+"."), entry.Lemma)
+		}
+
+		animals, err := lexicon.WithDomains("noun.animal")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(animals.Pool("noun", "b")).NotTo(BeEmpty())
+	})
+	It("Is only decompressed once", func() {
+		first, _ := EmbeddedLexicon()
+		second, _ := EmbeddedLexicon()
+
+		Expect(second).To(BeIdenticalTo(first))
+	})
+})
@@ -0,0 +1,241 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// wordListExtension is the extension of a word list file, the rest of the name is the part of speech
+const wordListExtension = ".txt"
+
+// csvTagSeparator separates the tags in the tags column of a CSV lexicon
+const csvTagSeparator = ";"
+
+// LoadLexicon loads a lexicon from whatever is at the path
+//
+// That can be a Global WordNet LMF XML file, a CSV or TSV file, a directory of WordNet dictionary files, or a
+// directory of word lists.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadLexicon("/tmp/words.csv")
+func LoadLexicon(path string) (*Lexicon, error) {
+	info, err := os.Stat(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() && IsLMFFile(path) {
+		return LoadLMFLexicon(path)
+	}
+
+	if !info.IsDir() {
+		return LoadCSVLexicon(path)
+	}
+
+	if IsWordNetDir(path) {
+		return LoadWordNetLexicon(path)
+	}
+
+	return LoadWordLists(path)
+}
+
+// IsWordNetDir returns true if the directory looks like it has WordNet dictionary files in it
+func IsWordNetDir(dir string) bool {
+	_, err := os.Stat(filepath.Join(dir, "data.noun"))
+
+	return err == nil
+}
+
+// LoadWordLists loads a directory of word lists, each file is named after the part of speech of its words
+//
+// Files ending in .txt are read, so adj.txt has adjectives and noun.txt has nouns. Anything else is ignored.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadWordLists("/tmp/words")
+func LoadWordLists(dir string) (*Lexicon, error) {
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lexicon := NewLexicon()
+
+	for _, info := range files {
+		if info.IsDir() || filepath.Ext(info.Name()) != wordListExtension {
+			continue
+		}
+
+		path := filepath.Join(dir, info.Name())
+		file, err := os.Open(path)
+
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := ReadWordList(file, strings.TrimSuffix(info.Name(), wordListExtension), path)
+		file.Close()
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %v", path, err)
+		}
+
+		lexicon.Add(entries...)
+	}
+
+	return lexicon, nil
+}
+
+// ReadWordList reads words of one part of speech, one per line
+//
+// Blank lines and lines starting with # are skipped.
+//
+// Could be used like
+//   entries, err := mnemonic.ReadWordList(strings.NewReader("apple\nbanana\n"), "noun", "fruit")
+func ReadWordList(reader io.Reader, partOfSpeech string, source string) ([]LexiconEntry, error) {
+	entries := []LexiconEntry{}
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+
+		if word == "" || strings.HasPrefix(word, "#") {
+			continue
+		}
+
+		entries = append(entries, LexiconEntry{Lemma: word, PartOfSpeech: partOfSpeech, Source: source})
+	}
+
+	return entries, scanner.Err()
+}
+
+// LoadCSVLexicon loads a CSV file of words, or a TSV file if the name ends in .tsv
+//
+// See ReadCSVLexicon for the columns.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadCSVLexicon("/tmp/words.csv")
+func LoadCSVLexicon(path string) (*Lexicon, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	comma := ','
+
+	if strings.ToLower(filepath.Ext(path)) == ".tsv" {
+		comma = '\t'
+	}
+
+	entries, err := ReadCSVLexicon(file, comma, path)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return NewLexicon(entries...), nil
+}
+
+// ReadCSVLexicon reads words from CSV, with the columns word, part of speech, tags and weight
+//
+// Tags and weight are optional, tags are separated by semicolons and a missing weight is 1. A first row starting
+// with "word" is taken to be a header and skipped, as are lines starting with #.
+//
+// Could be used like
+//   entries, err := mnemonic.ReadCSVLexicon(strings.NewReader("apple,noun,fruit;food,2\n"), ',', "fruit")
+func ReadCSVLexicon(reader io.Reader, comma rune, source string) ([]LexiconEntry, error) {
+	records := csv.NewReader(reader)
+	records.Comma = comma
+	records.Comment = '#'
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	entries := []LexiconEntry{}
+
+	for row := 1; ; row++ {
+		record, err := records.Read()
+
+		if err == io.EOF {
+			return entries, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if row == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "word") {
+			continue
+		}
+
+		entry, err := csvEntry(record, source)
+
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		entries = append(entries, entry)
+	}
+}
+
+// csvEntry turns a row of a CSV lexicon into an entry
+func csvEntry(record []string, source string) (LexiconEntry, error) {
+	if len(record) < 2 || len(record) > 4 {
+		return LexiconEntry{}, fmt.Errorf("expected 2 to 4 columns but found %d", len(record))
+	}
+
+	entry := LexiconEntry{
+		Lemma:        strings.TrimSpace(record[0]),
+		PartOfSpeech: strings.TrimSpace(record[1]),
+		Source:       source,
+	}
+
+	if entry.Lemma == "" || entry.PartOfSpeech == "" {
+		return LexiconEntry{}, fmt.Errorf("word and part of speech can't be empty")
+	}
+
+	if len(record) > 2 {
+		for _, tag := range strings.Split(record[2], csvTagSeparator) {
+			if tag = strings.TrimSpace(tag); tag != "" {
+				entry.Tags = append(entry.Tags, tag)
+			}
+		}
+	}
+
+	if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+		weight, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+
+		if err != nil || weight <= 0 {
+			return LexiconEntry{}, fmt.Errorf("weight %q isn't a number more than 0", record[3])
+		}
+
+		entry.Weight = weight
+	}
+
+	return entry, nil
+}
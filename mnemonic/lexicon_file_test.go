@@ -0,0 +1,145 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Lexicon files", func() {
+	var dir string
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "mnemonic-lexicon")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("Word lists", func() {
+		It("Reads one word a line, skipping blanks and comments", func() {
+			actual, err := ReadWordList(strings.NewReader("# fruit\napple\n\n  banana  \n"), "noun", "fruit")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal([]LexiconEntry{
+				{Lemma: "apple", PartOfSpeech: "noun", Source: "fruit"},
+				{Lemma: "banana", PartOfSpeech: "noun", Source: "fruit"},
+			}))
+		})
+		It("Takes the part of speech from the file name", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "noun.txt"), []byte("apple\n"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "adj.txt"), []byte("able\n"), 0644)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(dir, "README"), []byte("ignored\n"), 0644)).To(Succeed())
+
+			actual, err := LoadWordLists(dir)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.PartsOfSpeech()).To(Equal([]string{"adj", "noun"}))
+			Expect(actual.Pool("noun", "a")[0].Lemma).To(Equal("apple"))
+			Expect(actual.Len()).To(Equal(2))
+		})
+	})
+	Context("CSV", func() {
+		It("Reads words with optional tags and weights", func() {
+			actual, err := ReadCSVLexicon(strings.NewReader(
+				"word,pos,tags,weight\napple,noun,fruit; food,2\namble,verb\nbanana,noun,,0.5\n",
+			), ',', "test")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal([]LexiconEntry{
+				{Lemma: "apple", PartOfSpeech: "noun", Source: "test", Tags: []string{"fruit", "food"}, Weight: 2},
+				{Lemma: "amble", PartOfSpeech: "verb", Source: "test"},
+				{Lemma: "banana", PartOfSpeech: "noun", Source: "test", Weight: 0.5},
+			}))
+		})
+		It("Reads tab separated files", func() {
+			path := filepath.Join(dir, "words.tsv")
+			Expect(ioutil.WriteFile(path, []byte("apple\tnoun\n# comment\namble\tverb\n"), 0644)).To(Succeed())
+
+			actual, err := LoadCSVLexicon(path)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Len()).To(Equal(2))
+			Expect(actual.Pool("verb", "a")[0].Source).To(Equal(path))
+		})
+		It("Picks capitalised words", func() {
+			entries, err := ReadCSVLexicon(strings.NewReader("Kubernetes,noun\n"), ',', "test")
+			Expect(err).NotTo(HaveOccurred())
+
+			for seed := int64(0); seed < 10; seed++ {
+				actual, err := Generate(context.Background(), "k", WithLexicon(NewLexicon(entries...)), WithSeed(seed))
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Text()).To(Equal("Kubernetes."))
+			}
+		})
+		It("Says which row is wrong", func() {
+			_, err := ReadCSVLexicon(strings.NewReader("apple,noun\nbanana\n"), ',', "test")
+
+			Expect(err).To(MatchError("row 2: expected 2 to 4 columns but found 1"))
+		})
+		It("Rejects weights that aren't positive numbers", func() {
+			_, err := ReadCSVLexicon(strings.NewReader("apple,noun,,heavy\n"), ',', "test")
+			Expect(err).To(HaveOccurred())
+
+			_, err = ReadCSVLexicon(strings.NewReader("apple,noun,,0\n"), ',', "test")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+	Context("LoadLexicon", func() {
+		It("Loads CSV files", func() {
+			path := filepath.Join(dir, "words.csv")
+			Expect(ioutil.WriteFile(path, []byte("apple,noun\n"), 0644)).To(Succeed())
+
+			actual, err := LoadLexicon(path)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Len()).To(Equal(1))
+		})
+		It("Loads directories of word lists", func() {
+			Expect(ioutil.WriteFile(filepath.Join(dir, "verb.txt"), []byte("amble\n"), 0644)).To(Succeed())
+
+			actual, err := LoadLexicon(dir)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.PartsOfSpeech()).To(Equal([]string{"verb"}))
+		})
+		It("Errors when there's nothing there", func() {
+			_, err := LoadLexicon(filepath.Join(dir, "missing"))
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
+
+func ExampleReadCSVLexicon() {
+	entries, _ := ReadCSVLexicon(strings.NewReader("apple,noun,fruit,2\n"), ',', "fruit")
+
+	fmt.Println(entries[0].Lemma, entries[0].PartOfSpeech, entries[0].Tags, entries[0].Weight)
+	// Output: apple noun [fruit] 2
+}
@@ -0,0 +1,351 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// lexiconIndexVersion changes whenever the layout or meaning of the index does, so old indexes get rebuilt
+const lexiconIndexVersion = 7
+
+// lexiconIndexFields is the number of strings stored for each entry in the index
+const lexiconIndexFields = 10
+
+// indexTagSeparator joins an entry's tags, or its related synsets, into one string in the index
+const indexTagSeparator = "\x1f"
+
+// ErrStaleIndex is returned when a lexicon index was built from different source files than are there now
+var ErrStaleIndex = errors.New("lexicon index is out of date")
+
+// lexiconIndex is how a lexicon is stored on disk
+//
+// Every string is stored once in Strings, and each entry is lexiconIndexFields offsets into it
+type lexiconIndex struct {
+	Version   int
+	Checksums map[string]string
+	Strings   []string
+	Entries   []uint32
+}
+
+// LoadCachedWordNetLexicon returns the lexicon for a WordNet dictionary, using an index in cacheDir when it's up
+// to date
+//
+// The first time, or after the dictionary files change, the dictionary is loaded and a new index written.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadCachedWordNetLexicon("/tmp/dict", "/home/billie/.cache/mnemonic")
+func LoadCachedWordNetLexicon(dictDir string, cacheDir string) (*Lexicon, error) {
+	indexPath, err := WordNetIndexPath(dictDir, cacheDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lexicon, err := LoadLexiconIndex(indexPath, dictDir)
+
+	if err == nil {
+		return lexicon, nil
+	}
+
+	lexicon, err = LoadWordNetLexicon(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Not being able to write the cache only makes the next run slower
+	_ = SaveLexiconIndex(indexPath, lexicon, dictDir)
+
+	return lexicon, nil
+}
+
+// WordNetIndexPath returns where LoadCachedWordNetLexicon keeps the index for a dictionary
+//
+// Each dictionary directory gets its own index in the cache directory
+func WordNetIndexPath(dictDir string, cacheDir string) (string, error) {
+	absDictDir, err := filepath.Abs(dictDir)
+
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256([]byte(absDictDir))
+
+	return filepath.Join(cacheDir, fmt.Sprintf("wordnet-%s.idx", hex.EncodeToString(hash[:8]))), nil
+}
+
+// SaveLexiconIndex writes a lexicon to an index file, along with the checksums of the files in sourceDir
+//
+// Could be used like
+//   err := mnemonic.SaveLexiconIndex("/tmp/wordnet.idx", lexicon, "/tmp/dict")
+func SaveLexiconIndex(indexPath string, lexicon *Lexicon, sourceDir string) error {
+	checksums, err := directoryChecksums(sourceDir)
+
+	if err != nil {
+		return err
+	}
+
+	index := lexiconIndex{Version: lexiconIndexVersion, Checksums: checksums}
+	offsets := make(map[string]uint32)
+
+	for _, entry := range lexicon.Entries() {
+		weight, frequency := "", ""
+
+		if entry.Weight != 0 {
+			weight = strconv.FormatFloat(entry.Weight, 'g', -1, 64)
+		}
+
+		if entry.Frequency != 0 {
+			frequency = strconv.Itoa(entry.Frequency)
+		}
+
+		for _, field := range []string{
+			entry.Lemma,
+			entry.PartOfSpeech,
+			entry.SynsetID,
+			entry.Gloss,
+			entry.Source,
+			strings.Join(entry.Tags, indexTagSeparator),
+			weight,
+			frequency,
+			entry.Domain,
+			strings.Join(entry.Related, indexTagSeparator),
+		} {
+			offset, ok := offsets[field]
+
+			if !ok {
+				offset = uint32(len(index.Strings))
+				offsets[field] = offset
+				index.Strings = append(index.Strings, field)
+			}
+
+			index.Entries = append(index.Entries, offset)
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(indexPath), 0755)
+
+	if err != nil {
+		return err
+	}
+
+	// Write somewhere else first so a half written index is never read
+	file, err := ioutil.TempFile(filepath.Dir(indexPath), filepath.Base(indexPath))
+
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(file.Name())
+
+	writer := bufio.NewWriter(file)
+	err = gob.NewEncoder(writer).Encode(index)
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), indexPath)
+}
+
+// LoadLexiconIndex reads a lexicon from an index file
+//
+// ErrStaleIndex is returned if the files in sourceDir have changed since the index was written.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadLexiconIndex("/tmp/wordnet.idx", "/tmp/dict")
+func LoadLexiconIndex(indexPath string, sourceDir string) (*Lexicon, error) {
+	file, err := os.Open(indexPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	index := lexiconIndex{}
+	err = gob.NewDecoder(bufio.NewReader(file)).Decode(&index)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if index.Version != lexiconIndexVersion {
+		return nil, ErrStaleIndex
+	}
+
+	checksums, err := directoryChecksums(sourceDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !sameChecksums(index.Checksums, checksums) {
+		return nil, ErrStaleIndex
+	}
+
+	return index.lexicon()
+}
+
+// lexicon turns the index back into a lexicon
+func (index lexiconIndex) lexicon() (*Lexicon, error) {
+	if len(index.Entries)%lexiconIndexFields != 0 {
+		return nil, fmt.Errorf("lexicon index has %d offsets, which isn't whole entries", len(index.Entries))
+	}
+
+	fields := make([]string, lexiconIndexFields)
+	// Every sense in a synset has the same relations, so they share one slice
+	related := make(map[string][]string)
+	entries := make([]LexiconEntry, 0, len(index.Entries)/lexiconIndexFields)
+
+	for i := 0; i < len(index.Entries); i += lexiconIndexFields {
+		for field := range fields {
+			offset := index.Entries[i+field]
+
+			if int(offset) >= len(index.Strings) {
+				return nil, fmt.Errorf("lexicon index has an offset past the end of its strings")
+			}
+
+			fields[field] = index.Strings[offset]
+		}
+
+		entry := LexiconEntry{
+			Lemma:        fields[0],
+			PartOfSpeech: fields[1],
+			SynsetID:     fields[2],
+			Gloss:        fields[3],
+			Source:       fields[4],
+			Domain:       fields[8],
+		}
+
+		if fields[5] != "" {
+			entry.Tags = strings.Split(fields[5], indexTagSeparator)
+		}
+
+		if fields[6] != "" {
+			weight, err := strconv.ParseFloat(fields[6], 64)
+
+			if err != nil {
+				return nil, fmt.Errorf("lexicon index has a bad weight: %v", err)
+			}
+
+			entry.Weight = weight
+		}
+
+		if fields[7] != "" {
+			frequency, err := strconv.Atoi(fields[7])
+
+			if err != nil {
+				return nil, fmt.Errorf("lexicon index has a bad frequency: %v", err)
+			}
+
+			entry.Frequency = frequency
+		}
+
+		if fields[9] != "" {
+			if _, ok := related[fields[9]]; !ok {
+				related[fields[9]] = strings.Split(fields[9], indexTagSeparator)
+			}
+
+			entry.Related = related[fields[9]]
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return NewLexicon(entries...), nil
+}
+
+// directoryChecksums returns the SHA-256 of every file in a directory, keyed by file name
+func directoryChecksums(dir string) (map[string]string, error) {
+	files, err := ioutil.ReadDir(dir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	checksums := make(map[string]string)
+
+	for _, info := range files {
+		if !info.Mode().IsRegular() {
+			continue
+		}
+
+		checksum, err := fileChecksum(filepath.Join(dir, info.Name()))
+
+		if err != nil {
+			return nil, err
+		}
+
+		checksums[info.Name()] = checksum
+	}
+
+	return checksums, nil
+}
+
+// fileChecksum returns the SHA-256 of a file's contents
+func fileChecksum(path string) (string, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	hash := sha256.New()
+
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// sameChecksums returns true when both sets of checksums are for the same files with the same contents
+func sameChecksums(expected map[string]string, actual map[string]string) bool {
+	if len(expected) != len(actual) {
+		return false
+	}
+
+	for name, checksum := range expected {
+		if actual[name] != checksum {
+			return false
+		}
+	}
+
+	return true
+}
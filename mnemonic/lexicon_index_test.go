@@ -0,0 +1,117 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("LexiconIndex", func() {
+	var sourceDir string
+	var indexPath string
+
+	lexicon := NewLexicon(
+		LexiconEntry{
+			Lemma:        "apple",
+			PartOfSpeech: "noun",
+			SynsetID:     "1",
+			Gloss:        "a fruit",
+			Source:       "wordnet",
+			Domain:       "noun.food",
+			Related:      []string{"3", "4"},
+		},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", SynsetID: "2", Gloss: "a tree", Source: "wordnet"},
+		LexiconEntry{Lemma: "amble", PartOfSpeech: "verb", Gloss: "walk slowly", Source: "wordnet"},
+		LexiconEntry{Lemma: "bake", PartOfSpeech: "verb", Tags: []string{"food", "home"}, Weight: 2.5, Frequency: 7},
+	)
+
+	BeforeEach(func() {
+		var err error
+		sourceDir, err = ioutil.TempDir("", "mnemonic-source")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ioutil.WriteFile(filepath.Join(sourceDir, "data.noun"), []byte("apple"), 0644)).To(Succeed())
+
+		indexDir, err := ioutil.TempDir("", "mnemonic-index")
+		Expect(err).NotTo(HaveOccurred())
+		indexPath = filepath.Join(indexDir, "nested", "wordnet.idx")
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(sourceDir)
+		os.RemoveAll(filepath.Dir(filepath.Dir(indexPath)))
+	})
+
+	It("Reads back what was written", func() {
+		Expect(SaveLexiconIndex(indexPath, lexicon, sourceDir)).To(Succeed())
+
+		actual, err := LoadLexiconIndex(indexPath, sourceDir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Entries()).To(Equal(lexicon.Entries()))
+	})
+	It("Is stale when a source file changes", func() {
+		Expect(SaveLexiconIndex(indexPath, lexicon, sourceDir)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(sourceDir, "data.noun"), []byte("avocado"), 0644)).To(Succeed())
+
+		_, err := LoadLexiconIndex(indexPath, sourceDir)
+
+		Expect(err).To(Equal(ErrStaleIndex))
+	})
+	It("Is stale when a source file is added", func() {
+		Expect(SaveLexiconIndex(indexPath, lexicon, sourceDir)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(sourceDir, "data.verb"), []byte("amble"), 0644)).To(Succeed())
+
+		_, err := LoadLexiconIndex(indexPath, sourceDir)
+
+		Expect(err).To(Equal(ErrStaleIndex))
+	})
+	It("Errors when there's no index", func() {
+		_, err := LoadLexiconIndex(indexPath, sourceDir)
+
+		Expect(err).To(HaveOccurred())
+	})
+	It("Uses an up to date index instead of loading the dictionary", func() {
+		cacheDir := filepath.Dir(indexPath)
+		cachedPath, err := WordNetIndexPath(sourceDir, cacheDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Dir(cachedPath)).To(Equal(cacheDir))
+
+		// The source directory isn't a real dictionary, so this only works if the index is used
+		Expect(SaveLexiconIndex(cachedPath, lexicon, sourceDir)).To(Succeed())
+
+		actual, err := LoadCachedWordNetLexicon(sourceDir, cacheDir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Entries()).To(Equal(lexicon.Entries()))
+	})
+	It("Loads the dictionary when the index is stale", func() {
+		cacheDir := filepath.Dir(indexPath)
+		cachedPath, err := WordNetIndexPath(sourceDir, cacheDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(SaveLexiconIndex(cachedPath, lexicon, sourceDir)).To(Succeed())
+		Expect(ioutil.WriteFile(filepath.Join(sourceDir, "data.noun"), []byte("avocado"), 0644)).To(Succeed())
+
+		_, err = LoadCachedWordNetLexicon(sourceDir, cacheDir)
+
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,225 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/xml"
+	"io"
+	"os"
+	"strings"
+)
+
+// lmfPartsOfSpeech maps the part of speech codes in LMF files to the names templates use
+var lmfPartsOfSpeech = map[string]string{
+	"n": "noun",
+	"v": "verb",
+	"a": "adj",
+	"s": "adj",
+	"r": "adv",
+	"c": "conj",
+	"p": "prep",
+}
+
+// lmfLexicalEntry is a word in an LMF file, and the synsets it has a sense in
+type lmfLexicalEntry struct {
+	Lemma struct {
+		WrittenForm  string `xml:"writtenForm,attr"`
+		PartOfSpeech string `xml:"partOfSpeech,attr"`
+	} `xml:"Lemma"`
+	Senses []struct {
+		Synset string `xml:"synset,attr"`
+		Counts []int  `xml:"Count"`
+	} `xml:"Sense"`
+}
+
+// lmfSynset is a meaning in an LMF file
+type lmfSynset struct {
+	ID          string   `xml:"id,attr"`
+	Lexfile     string   `xml:"lexfile,attr"`
+	Definitions []string `xml:"Definition"`
+	Relations   []struct {
+		Type   string `xml:"relType,attr"`
+		Target string `xml:"target,attr"`
+	} `xml:"SynsetRelation"`
+}
+
+// lmfUsageRelation is the relation from a synset to the usage domain it's in, like slang
+const lmfUsageRelation = "exemplifies"
+
+// IsLMFFile returns true if the path looks like a Global WordNet LMF XML file, compressed or not
+func IsLMFFile(path string) bool {
+	lower := strings.ToLower(path)
+
+	return strings.HasSuffix(lower, ".xml") || strings.HasSuffix(lower, ".xml.gz")
+}
+
+// LoadLMFLexicon loads a Global WordNet LMF XML file, like the ones Open English WordNet releases
+//
+// Files ending in .gz are decompressed as they're read.
+//
+// Get Open English WordNet from https://en-word.net/
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadLMFLexicon("/tmp/english-wordnet-2023.xml.gz")
+func LoadLMFLexicon(path string) (*Lexicon, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	var reader io.Reader = bufio.NewReader(file)
+
+	if strings.HasSuffix(strings.ToLower(path), ".gz") {
+		gzipReader, err := gzip.NewReader(reader)
+
+		if err != nil {
+			return nil, err
+		}
+
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	return ReadLMFLexicon(reader)
+}
+
+// ReadLMFLexicon reads a Global WordNet LMF XML document
+//
+// There's an entry for each sense of each word, with the synset's first definition as the gloss and the id of the
+// lexicon in the file as the source. The counts of all the word's senses add up to its frequency, and senses in a
+// usage domain, like slang, are tagged with it. The synset's lexicographer file, like noun.food, is the domain. The
+// document is streamed rather than read into memory all at once.
+//
+// Could be used like
+//   lexicon, err := mnemonic.ReadLMFLexicon(file)
+func ReadLMFLexicon(reader io.Reader) (*Lexicon, error) {
+	decoder := xml.NewDecoder(reader)
+	interner := newStringInterner()
+	entries := []LexiconEntry{}
+	glosses := make(map[string]string)
+	domains := make(map[string]string)
+	usages := make(map[string][]string)
+	source := ""
+
+	for {
+		token, err := decoder.Token()
+
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+
+		if !ok {
+			continue
+		}
+
+		switch start.Name.Local {
+		case "Lexicon":
+			source = lmfAttr(start, "id")
+		case "LexicalEntry":
+			lexicalEntry := lmfLexicalEntry{}
+
+			if err := decoder.DecodeElement(&lexicalEntry, &start); err != nil {
+				return nil, err
+			}
+
+			partOfSpeech, ok := lmfPartsOfSpeech[lexicalEntry.Lemma.PartOfSpeech]
+
+			if !ok {
+				continue
+			}
+
+			frequency := 0
+
+			for _, sense := range lexicalEntry.Senses {
+				for _, count := range sense.Counts {
+					frequency += count
+				}
+			}
+
+			for _, sense := range lexicalEntry.Senses {
+				entries = append(entries, LexiconEntry{
+					Lemma:        interner.intern(lexicalEntry.Lemma.WrittenForm),
+					PartOfSpeech: partOfSpeech,
+					SynsetID:     sense.Synset,
+					Source:       interner.intern(source),
+					Frequency:    frequency,
+				})
+			}
+		case "Synset":
+			synset := lmfSynset{}
+
+			if err := decoder.DecodeElement(&synset, &start); err != nil {
+				return nil, err
+			}
+
+			if len(synset.Definitions) > 0 {
+				glosses[synset.ID] = interner.intern(synset.Definitions[0])
+			}
+
+			domains[synset.ID] = interner.intern(synset.Lexfile)
+
+			for _, relation := range synset.Relations {
+				if relation.Type == lmfUsageRelation {
+					usages[synset.ID] = append(usages[synset.ID], relation.Target)
+				}
+			}
+		}
+	}
+
+	// Synsets come after the words in LMF files, so glosses and usages can only be filled in at the end
+	synsetWords := make(map[string][]string)
+
+	for i := range entries {
+		entries[i].Gloss = glosses[entries[i].SynsetID]
+		entries[i].Domain = domains[entries[i].SynsetID]
+		synsetWords[entries[i].SynsetID] = append(synsetWords[entries[i].SynsetID], entries[i].Lemma)
+	}
+
+	for i := range entries {
+		for _, domain := range usages[entries[i].SynsetID] {
+			for _, usage := range synsetWords[domain] {
+				entries[i].Tags = appendTag(entries[i].Tags, UsageTag(usage))
+			}
+		}
+	}
+
+	lexicon := NewLexicon(entries...)
+	lexicon.sortPools()
+
+	return lexicon, nil
+}
+
+// lmfAttr returns the value of an attribute of an element, or an empty string if it doesn't have it
+func lmfAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+
+	return ""
+}
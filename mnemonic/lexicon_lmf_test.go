@@ -0,0 +1,147 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// testLMF is a cut down Open English WordNet
+const testLMF = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE LexicalResource SYSTEM "http://globalwordnet.github.io/schemas/WN-LMF-1.1.dtd">
+<LexicalResource xmlns:dc="https://globalwordnet.github.io/schemas/dc/">
+  <Lexicon id="oewn" label="Open English WordNet" language="en" email="" license="" version="2023">
+    <LexicalEntry id="oewn-apple-n">
+      <Lemma writtenForm="apple" partOfSpeech="n"/>
+      <Sense id="oewn-apple__1.13.00.." synset="oewn-07755101-n">
+        <Count>3</Count>
+      </Sense>
+      <Sense id="oewn-apple__1.20.00.." synset="oewn-12651821-n"/>
+    </LexicalEntry>
+    <LexicalEntry id="oewn-able-s">
+      <Lemma writtenForm="able" partOfSpeech="s"/>
+      <Sense id="oewn-able__5.00.00.capable.00" synset="oewn-00001740-s"/>
+    </LexicalEntry>
+    <LexicalEntry id="oewn-x-x">
+      <Lemma writtenForm="xyzzy" partOfSpeech="x"/>
+      <Sense id="oewn-xyzzy" synset="oewn-00000001-x"/>
+    </LexicalEntry>
+    <Synset id="oewn-07755101-n" ili="i77419" partOfSpeech="n" lexfile="noun.food">
+      <Definition>fruit with red or yellow or green skin</Definition>
+    </Synset>
+    <Synset id="oewn-12651821-n" ili="i107413" partOfSpeech="n" lexfile="noun.plant">
+      <Definition>native Eurasian tree widely cultivated</Definition>
+    </Synset>
+    <Synset id="oewn-00001740-s" ili="i2" partOfSpeech="s" lexfile="adj.all">
+      <Definition>have the skills and qualifications to do things well</Definition>
+      <Definition>a second definition</Definition>
+    </Synset>
+  </Lexicon>
+</LexicalResource>
+`
+
+var _ = Describe("LMF lexicons", func() {
+	It("Has an entry for each sense with its synset's definition and the word's counts", func() {
+		lexicon, err := ReadLMFLexicon(strings.NewReader(testLMF))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lexicon.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{
+				Lemma:        "apple",
+				PartOfSpeech: "noun",
+				SynsetID:     "oewn-07755101-n",
+				Gloss:        "fruit with red or yellow or green skin",
+				Source:       "oewn",
+				Frequency:    3,
+				Domain:       "noun.food",
+			},
+			{
+				Lemma:        "apple",
+				PartOfSpeech: "noun",
+				SynsetID:     "oewn-12651821-n",
+				Gloss:        "native Eurasian tree widely cultivated",
+				Source:       "oewn",
+				Frequency:    3,
+				Domain:       "noun.plant",
+			},
+		}))
+	})
+	It("Treats adjective satellites as adjectives and skips unknown parts of speech", func() {
+		lexicon, err := ReadLMFLexicon(strings.NewReader(testLMF))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lexicon.PartsOfSpeech()).To(Equal([]string{"adj", "noun"}))
+		Expect(lexicon.Pool("adj", "a")[0].Gloss).To(Equal("have the skills and qualifications to do things well"))
+		Expect(lexicon.Len()).To(Equal(3))
+	})
+	It("Tags senses in a usage domain", func() {
+		lexicon, err := ReadLMFLexicon(strings.NewReader(`<LexicalResource><Lexicon id="oewn">
+			<LexicalEntry><Lemma writtenForm="gnarly" partOfSpeech="s"/><Sense synset="oewn-1-s"/></LexicalEntry>
+			<LexicalEntry><Lemma writtenForm="slang" partOfSpeech="n"/><Sense synset="oewn-2-n"/></LexicalEntry>
+			<Synset id="oewn-1-s"><SynsetRelation relType="exemplifies" target="oewn-2-n"/></Synset>
+			<Synset id="oewn-2-n"/>
+		</Lexicon></LexicalResource>`))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lexicon.Pool("adj", "g")[0].Tags).To(Equal([]string{UsageTag("slang")}))
+		Expect(lexicon.Pool("noun", "s")[0].Tags).To(BeEmpty())
+	})
+	It("Errors on broken XML", func() {
+		_, err := ReadLMFLexicon(strings.NewReader("<LexicalResource><Lexicon>"))
+
+		Expect(err).To(HaveOccurred())
+	})
+	It("Loads compressed files", func() {
+		dir, err := ioutil.TempDir("", "mnemonic-lmf")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		path := filepath.Join(dir, "english-wordnet.xml.gz")
+		file, err := os.Create(path)
+		Expect(err).NotTo(HaveOccurred())
+
+		writer := gzip.NewWriter(file)
+		_, err = writer.Write([]byte(testLMF))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(writer.Close()).To(Succeed())
+		Expect(file.Close()).To(Succeed())
+
+		lexicon, err := LoadLexicon(path)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lexicon.Len()).To(Equal(3))
+	})
+})
+
+func ExampleReadLMFLexicon() {
+	lexicon, _ := ReadLMFLexicon(strings.NewReader(testLMF))
+
+	for _, entry := range lexicon.Pool("noun", "a") {
+		fmt.Println(entry.Lemma, entry.SynsetID)
+	}
+	// Output:
+	// apple oewn-07755101-n
+	// apple oewn-12651821-n
+}
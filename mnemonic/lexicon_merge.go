@@ -0,0 +1,68 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+// LexiconSource is a lexicon to merge with others, and how much its words count for
+type LexiconSource struct {
+	Lexicon *Lexicon
+	// Name replaces the source of every entry from this lexicon, when it's set
+	Name string
+	// Weight multiplies the weight of every entry from this lexicon, zero counts as 1
+	Weight float64
+}
+
+// MergeLexicons returns one lexicon with the words from all the sources
+//
+// A word is only taken from the first source that has it with that part of speech, so sources given earlier take
+// precedence. Every sense of the word in that source is kept. Entries keep their source, so you can tell where a
+// word came from.
+//
+// Could be used like
+//   lexicon := mnemonic.MergeLexicons(
+//     mnemonic.LexiconSource{Lexicon: team, Name: "team", Weight: 3},
+//     mnemonic.LexiconSource{Lexicon: wordNet},
+//   )
+func MergeLexicons(sources ...LexiconSource) *Lexicon {
+	owners := make(map[string]int)
+	entries := []LexiconEntry{}
+
+	for i, source := range sources {
+		for _, entry := range source.Lexicon.Entries() {
+			key := wordKey(entry.Lemma, entry.PartOfSpeech)
+
+			if owner, ok := owners[key]; ok && owner != i {
+				continue
+			}
+
+			owners[key] = i
+
+			if source.Name != "" {
+				entry.Source = source.Name
+			}
+
+			if source.Weight > 0 && source.Weight != 1 {
+				entry.Weight = entry.effectiveWeight() * source.Weight
+			}
+
+			entries = append(entries, entry)
+		}
+	}
+
+	lexicon := NewLexicon(entries...)
+	lexicon.sortPools()
+
+	return lexicon
+}
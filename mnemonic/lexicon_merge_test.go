@@ -0,0 +1,80 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("MergeLexicons", func() {
+	team := NewLexicon(
+		LexiconEntry{Lemma: "Apple", PartOfSpeech: "noun", Source: "team.csv"},
+		LexiconEntry{Lemma: "acme", PartOfSpeech: "noun", Source: "team.csv", Weight: 2},
+	)
+	wordNet := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a fruit", Source: "wordnet"},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a tree", Source: "wordnet"},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "adj", Source: "wordnet"},
+		LexiconEntry{Lemma: "amble", PartOfSpeech: "verb", Source: "wordnet"},
+	)
+
+	It("Takes each word from the first source that has it", func() {
+		merged := MergeLexicons(LexiconSource{Lexicon: team}, LexiconSource{Lexicon: wordNet})
+
+		Expect(merged.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{Lemma: "Apple", PartOfSpeech: "noun", Source: "team.csv"},
+			{Lemma: "acme", PartOfSpeech: "noun", Source: "team.csv", Weight: 2},
+		}))
+		Expect(merged.Pool("adj", "a")).To(HaveLen(1))
+		Expect(merged.Pool("verb", "a")).To(HaveLen(1))
+	})
+	It("Keeps every sense from the source that wins", func() {
+		merged := MergeLexicons(LexiconSource{Lexicon: wordNet}, LexiconSource{Lexicon: team})
+
+		Expect(merged.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{Lemma: "acme", PartOfSpeech: "noun", Source: "team.csv", Weight: 2},
+			{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a fruit", Source: "wordnet"},
+			{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a tree", Source: "wordnet"},
+		}))
+	})
+	It("Weights and renames sources", func() {
+		merged := MergeLexicons(LexiconSource{Lexicon: team, Name: "team", Weight: 3})
+
+		Expect(merged.Pool("noun", "a")).To(Equal([]LexiconEntry{
+			{Lemma: "Apple", PartOfSpeech: "noun", Source: "team", Weight: 3},
+			{Lemma: "acme", PartOfSpeech: "noun", Source: "team", Weight: 6},
+		}))
+	})
+})
+
+func ExampleMergeLexicons() {
+	team := NewLexicon(LexiconEntry{Lemma: "acme", PartOfSpeech: "noun", Source: "team"})
+	wordNet := NewLexicon(
+		LexiconEntry{Lemma: "acme", PartOfSpeech: "noun", Source: "wordnet"},
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Source: "wordnet"},
+	)
+
+	for _, entry := range MergeLexicons(LexiconSource{Lexicon: team}, LexiconSource{Lexicon: wordNet}).Pool("noun", "a") {
+		fmt.Println(entry.Lemma, entry.Source)
+	}
+	// Output:
+	// acme team
+	// apple wordnet
+}
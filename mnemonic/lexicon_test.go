@@ -0,0 +1,148 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+
+	"fmt"
+)
+
+var _ = Describe("Lexicon", func() {
+	Context("Pools", func() {
+		It("Groups entries by part of speech and first letter", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+				LexiconEntry{Lemma: "banana", PartOfSpeech: "noun"},
+			)
+
+			Expect(lexicon.Pool("noun", "a")).To(Equal([]LexiconEntry{
+				{Lemma: "apple", PartOfSpeech: "noun"},
+				{Lemma: "avocado", PartOfSpeech: "noun"},
+			}))
+			Expect(lexicon.Pool("verb", "a")).To(Equal([]LexiconEntry{{Lemma: "amble", PartOfSpeech: "verb"}}))
+			Expect(lexicon.Pool("verb", "b")).To(BeEmpty())
+			Expect(lexicon.Pool("adj", "a")).To(BeEmpty())
+			Expect(lexicon.Len()).To(Equal(4))
+		})
+		It("Ignores empty words", func() {
+			lexicon := NewLexicon(LexiconEntry{PartOfSpeech: "noun"})
+
+			Expect(lexicon.Len()).To(Equal(0))
+		})
+	})
+	Context("Parts of speech", func() {
+		It("Lists the template functions first, then the rest by name", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "the", PartOfSpeech: "det"},
+				LexiconEntry{Lemma: "quickly", PartOfSpeech: "adv"},
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "and", PartOfSpeech: "conj"},
+			)
+
+			Expect(lexicon.PartsOfSpeech()).To(Equal([]string{"noun", "adv", "conj", "det"}))
+		})
+	})
+	Context("Pick", func() {
+		It("Picks every entry when none have weights", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun"},
+			)
+			random := NewSeededRandomSource(1)
+			picked := make(map[string]bool)
+
+			for i := 0; i < 50; i++ {
+				entry, ok := lexicon.Pick("noun", "a", random)
+
+				Expect(ok).To(BeTrue())
+				picked[entry.Lemma] = true
+			}
+
+			Expect(picked).To(HaveLen(2))
+		})
+		It("Picks heavier entries more often", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Weight: 0.1},
+				LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun", Weight: 9.9},
+			)
+			random := NewSeededRandomSource(1)
+			heavy := 0
+
+			for i := 0; i < 1000; i++ {
+				if entry, _ := lexicon.Pick("noun", "a", random); entry.Lemma == "avocado" {
+					heavy++
+				}
+			}
+
+			Expect(heavy).To(BeNumerically(">", 950))
+			Expect(heavy).To(BeNumerically("<", 1000))
+		})
+		It("Still picks the heaviest entries when the weights are too big to add up", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Weight: 1},
+				LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun", Weight: 1e30},
+				LexiconEntry{Lemma: "azure", PartOfSpeech: "noun", Weight: 1e30},
+			)
+			random := NewSeededRandomSource(1)
+			light := 0
+
+			for i := 0; i < 1000; i++ {
+				if entry, _ := lexicon.Pick("noun", "a", random); entry.Lemma == "apple" {
+					light++
+				}
+			}
+
+			Expect(light).To(BeNumerically("<", 5))
+		})
+		It("Returns false when there's nothing to pick", func() {
+			_, ok := NewLexicon().Pick("noun", "a", NewSeededRandomSource(1))
+
+			Expect(ok).To(BeFalse())
+		})
+	})
+	Context("Entries", func() {
+		It("Returns everything grouped by part of speech and letter", func() {
+			lexicon := NewLexicon(
+				LexiconEntry{Lemma: "banana", PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+				LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+			)
+
+			Expect(lexicon.Entries()).To(Equal([]LexiconEntry{
+				{Lemma: "apple", PartOfSpeech: "noun"},
+				{Lemma: "banana", PartOfSpeech: "noun"},
+				{Lemma: "amble", PartOfSpeech: "verb"},
+			}))
+		})
+	})
+})
+
+func ExampleLexicon_Pool() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+	)
+
+	for _, entry := range lexicon.Pool("noun", "a") {
+		fmt.Println(entry.Lemma)
+	}
+	// Output: apple
+}
@@ -0,0 +1,162 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/lloyd/wnram"
+)
+
+// LoadWordNetLexicon loads the WordNet dictionary files in a directory into a lexicon
+//
+// Words in a usage domain, like slang, are tagged with it, and each word gets its synset id and lexicographer file
+// as its domain. When the dictionary has a cntlist.rev file, how often each word was seen in tagged text is used as
+// its frequency.
+//
+// Could be used like
+//   lexicon, err := mnemonic.LoadWordNetLexicon("/tmp/dict")
+func LoadWordNetLexicon(dictDir string) (*Lexicon, error) {
+	wn, err := LoadWordNet(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	details, err := loadWordNetDetails(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	lexicon := details.apply(NewWordNetLexicon(wn))
+	cntlistPath := filepath.Join(dictDir, cntlistFile)
+
+	if _, err := os.Stat(cntlistPath); err != nil {
+		return lexicon, nil
+	}
+
+	frequencies, err := LoadCntlist(cntlistPath)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return frequencies.Apply(lexicon), nil
+}
+
+// NewWordNetLexicon returns a lexicon with every word in a WordNet dictionary
+//
+// The dictionary is walked once for all parts of speech, and strings that repeat, like the gloss shared by every
+// word in a synset, are only stored once.
+//
+// Could be used like
+//   wn, _ := mnemonic.LoadWordNet(dictDir)
+//   lexicon := mnemonic.NewWordNetLexicon(wn)
+func NewWordNetLexicon(wn *wnram.Handle) *Lexicon {
+	return newWordNetLexicon(wn, wordNetPartsOfSpeech())
+}
+
+// newWordNetLexicon returns a lexicon with the words in a WordNet dictionary for only the parts of speech given
+func newWordNetLexicon(wn *wnram.Handle, partsOfSpeech wnram.PartOfSpeechList) *Lexicon {
+	lexicon := NewLexicon()
+	strings := newStringInterner()
+
+	wn.Iterate(partsOfSpeech, func(word wnram.Lookup) error {
+		lexicon.Add(strings.wordNetEntry(&word))
+
+		return nil
+	})
+
+	lexicon.sortPools()
+
+	return lexicon
+}
+
+// NewWordNetLexiconParallel returns the same lexicon as NewWordNetLexicon, walking each part of speech at once
+//
+// Could be used like
+//   wn, _ := mnemonic.LoadWordNet(dictDir)
+//   lexicon := mnemonic.NewWordNetLexiconParallel(wn)
+func NewWordNetLexiconParallel(wn *wnram.Handle) *Lexicon {
+	partsOfSpeech := wordNetPartsOfSpeech()
+	found := make([][]LexiconEntry, len(partsOfSpeech))
+	waitGroup := sync.WaitGroup{}
+
+	for i := range partsOfSpeech {
+		waitGroup.Add(1)
+
+		go func(i int) {
+			defer waitGroup.Done()
+
+			strings := newStringInterner()
+
+			wn.Iterate(wnram.PartOfSpeechList{partsOfSpeech[i]}, func(word wnram.Lookup) error {
+				found[i] = append(found[i], strings.wordNetEntry(&word))
+
+				return nil
+			})
+		}(i)
+	}
+
+	waitGroup.Wait()
+
+	lexicon := NewLexicon()
+
+	for i := range found {
+		lexicon.Add(found[i]...)
+	}
+
+	lexicon.sortPools()
+
+	return lexicon
+}
+
+// wordNetPartsOfSpeech returns the parts of speech in WordNet, in the order the functions prefer them
+func wordNetPartsOfSpeech() wnram.PartOfSpeechList {
+	return wnram.PartOfSpeechList{wnram.Adjective, wnram.Noun, wnram.Verb, wnram.Adverb}
+}
+
+// stringInterner makes sure equal strings share the same memory
+type stringInterner map[string]string
+
+// newStringInterner returns an interner with nothing in it yet
+func newStringInterner() stringInterner {
+	return make(stringInterner)
+}
+
+// intern returns the copy of the string we already have, keeping this one if it's new
+func (s stringInterner) intern(value string) string {
+	if existing, ok := s[value]; ok {
+		return existing
+	}
+
+	s[value] = value
+
+	return value
+}
+
+// wordNetEntry turns a WordNet word into a lexicon entry, with its strings interned
+func (s stringInterner) wordNetEntry(word *wnram.Lookup) LexiconEntry {
+	return LexiconEntry{
+		Lemma:        s.intern(word.Word()),
+		PartOfSpeech: word.POS().String(),
+		Gloss:        s.intern(word.Gloss()),
+		Source:       wordNetSource,
+	}
+}
@@ -0,0 +1,182 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/lloyd/wnram"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// testDictionary returns a WordNet handle for the dictionary in MNEMONIC_DICT, or nil when it isn't set
+func testDictionary() *wnram.Handle {
+	dictDir := os.Getenv("MNEMONIC_DICT")
+
+	if dictDir == "" {
+		return nil
+	}
+
+	wn, err := LoadWordNet(dictDir)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return wn
+}
+
+var _ = Describe("WordNetLexicon", func() {
+	It("Builds the same lexicon in parallel", func() {
+		wn := testDictionary()
+
+		if wn == nil {
+			Skip("set MNEMONIC_DICT to a WordNet dictionary to run this")
+		}
+
+		Expect(NewWordNetLexiconParallel(wn).Entries()).To(Equal(NewWordNetLexicon(wn).Entries()))
+	})
+})
+
+// benchmarkDictionary returns the WordNet handle for benchmarks, skipping them without one
+func benchmarkDictionary(b *testing.B) *wnram.Handle {
+	wn := testDictionary()
+
+	if wn == nil {
+		b.Skip("set MNEMONIC_DICT to a WordNet dictionary to run benchmarks")
+	}
+
+	return wn
+}
+
+// retainedHeap returns how much heap is in use after a garbage collection
+func retainedHeap() uint64 {
+	stats := runtime.MemStats{}
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+
+	return stats.HeapAlloc
+}
+
+// BenchmarkPerPartOfSpeechWordLists is how NewWnramWordGenerator used to work, walking the dictionary for each
+// part of speech and keeping every wnram.Lookup
+func BenchmarkPerPartOfSpeechWordLists(b *testing.B) {
+	wn := benchmarkDictionary(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		before := retainedHeap()
+		wordLists := []map[string][]wnram.Lookup{}
+
+		for _, partOfSpeech := range []wnram.PartOfSpeech{wnram.Adjective, wnram.Noun, wnram.Verb, wnram.Adverb} {
+			wordList := make(map[string][]wnram.Lookup)
+
+			wn.Iterate(wnram.PartOfSpeechList{partOfSpeech}, func(word wnram.Lookup) error {
+				letter := string([]rune(word.Word())[0])
+				wordList[letter] = append(wordList[letter], word)
+
+				return nil
+			})
+
+			wordLists = append(wordLists, wordList)
+		}
+
+		b.Logf("retained %d bytes", retainedHeap()-before)
+		runtime.KeepAlive(wordLists)
+	}
+}
+
+func BenchmarkWnramWordGeneratorPerPartOfSpeech(b *testing.B) {
+	wn := benchmarkDictionary(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		before := retainedHeap()
+		generators := []*WnramWordGenerator{}
+
+		for _, partOfSpeech := range []wnram.PartOfSpeech{wnram.Adjective, wnram.Noun, wnram.Verb, wnram.Adverb} {
+			generators = append(generators, NewWnramWordGenerator(wn, partOfSpeech, NewSeededRandomSource(42)))
+		}
+
+		b.Logf("retained %d bytes", retainedHeap()-before)
+		runtime.KeepAlive(generators)
+	}
+}
+
+func BenchmarkWordNetLexicon(b *testing.B) {
+	wn := benchmarkDictionary(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		before := retainedHeap()
+		lexicon := NewWordNetLexicon(wn)
+
+		b.Logf("retained %d bytes", retainedHeap()-before)
+		runtime.KeepAlive(lexicon)
+	}
+}
+
+func BenchmarkWordNetLexiconParallel(b *testing.B) {
+	wn := benchmarkDictionary(b)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lexicon := NewWordNetLexiconParallel(wn)
+
+		runtime.KeepAlive(lexicon)
+	}
+}
+
+func BenchmarkLoadLexiconIndex(b *testing.B) {
+	wn := benchmarkDictionary(b)
+	dictDir := os.Getenv("MNEMONIC_DICT")
+	cacheDir, err := ioutil.TempDir("", "mnemonic-benchmark")
+
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	defer os.RemoveAll(cacheDir)
+
+	indexPath := filepath.Join(cacheDir, "wordnet.idx")
+
+	if err := SaveLexiconIndex(indexPath, NewWordNetLexicon(wn), dictDir); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		lexicon, err := LoadLexiconIndex(indexPath, dictDir)
+
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		runtime.KeepAlive(lexicon)
+	}
+}
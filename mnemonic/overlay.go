@@ -0,0 +1,290 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// OverlayAction is what an overlay does to a word
+type OverlayAction string
+
+const (
+	// OverlayAdd adds a word, as well as putting it in the overlay's own lexicon
+	OverlayAdd OverlayAction = "add"
+	// OverlayBoost makes a word more likely, multiplying its weight, and adds it if it isn't there
+	OverlayBoost OverlayAction = "boost"
+	// OverlaySuppress takes a word out so it's never picked
+	OverlaySuppress OverlayAction = "suppress"
+)
+
+// defaultBoost is how much more likely a boosted word is when the overlay doesn't say
+const defaultBoost = 2
+
+// OverlayEntry is a change an overlay makes to a word
+//
+// The entry's Weight is the weight of an added word, or how many times more likely a boosted word is. Suppressing
+// a word with no part of speech suppresses it for every part of speech.
+type OverlayEntry struct {
+	LexiconEntry
+	Action OverlayAction
+}
+
+// Overlay is a set of words to add to, boost in, or take out of another lexicon
+type Overlay struct {
+	entries []OverlayEntry
+}
+
+// NewOverlay returns an overlay that makes the given changes
+//
+// Could be used like
+//   overlay := mnemonic.NewOverlay(
+//     mnemonic.OverlayEntry{
+//       Action:       mnemonic.OverlayAdd,
+//       LexiconEntry: mnemonic.LexiconEntry{Lemma: "gopher", PartOfSpeech: "noun"},
+//     },
+//     mnemonic.OverlayEntry{Action: mnemonic.OverlaySuppress, LexiconEntry: mnemonic.LexiconEntry{Lemma: "moist"}},
+//   )
+func NewOverlay(entries ...OverlayEntry) *Overlay {
+	return &Overlay{entries: entries}
+}
+
+// LoadOverlay loads an overlay from a CSV file, or a TSV file if the name ends in .tsv
+//
+// See ReadOverlay for the columns.
+//
+// Could be used like
+//   overlay, err := mnemonic.LoadOverlay("/tmp/team-words.csv")
+func LoadOverlay(path string) (*Overlay, error) {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer file.Close()
+
+	comma := ','
+
+	if strings.ToLower(filepath.Ext(path)) == ".tsv" {
+		comma = '\t'
+	}
+
+	overlay, err := ReadOverlay(file, comma, path)
+
+	if err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+
+	return overlay, nil
+}
+
+// ReadOverlay reads an overlay from CSV, with the columns action, word, part of speech and weight
+//
+// The action is one of add, boost or suppress. The weight is optional, boosts default to doubling the chance of a
+// word. A first row starting with "action" is taken to be a header and skipped, as are lines starting with #.
+//
+// Could be used like
+//   overlay, err := mnemonic.ReadOverlay(strings.NewReader("add,gopher,noun\nsuppress,moist,\n"), ',', "team")
+func ReadOverlay(reader io.Reader, comma rune, source string) (*Overlay, error) {
+	records := csv.NewReader(reader)
+	records.Comma = comma
+	records.Comment = '#'
+	records.FieldsPerRecord = -1
+	records.TrimLeadingSpace = true
+
+	overlay := NewOverlay()
+
+	for row := 1; ; row++ {
+		record, err := records.Read()
+
+		if err == io.EOF {
+			return overlay, nil
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if row == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "action") {
+			continue
+		}
+
+		entry, err := overlayEntry(record, source)
+
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		overlay.entries = append(overlay.entries, entry)
+	}
+}
+
+// overlayEntry turns a row of an overlay file into an entry
+func overlayEntry(record []string, source string) (OverlayEntry, error) {
+	if len(record) < 2 || len(record) > 4 {
+		return OverlayEntry{}, fmt.Errorf("expected 2 to 4 columns but found %d", len(record))
+	}
+
+	entry := OverlayEntry{
+		Action:       OverlayAction(strings.ToLower(strings.TrimSpace(record[0]))),
+		LexiconEntry: LexiconEntry{Lemma: strings.TrimSpace(record[1]), Source: source},
+	}
+
+	if len(record) > 2 {
+		entry.PartOfSpeech = strings.TrimSpace(record[2])
+	}
+
+	switch {
+	case entry.Action != OverlayAdd && entry.Action != OverlayBoost && entry.Action != OverlaySuppress:
+		return OverlayEntry{}, fmt.Errorf("action %q isn't one of add, boost or suppress", record[0])
+	case entry.Lemma == "":
+		return OverlayEntry{}, fmt.Errorf("word can't be empty")
+	case entry.PartOfSpeech == "" && entry.Action != OverlaySuppress:
+		return OverlayEntry{}, fmt.Errorf("only suppress can leave out the part of speech")
+	}
+
+	if len(record) > 3 && strings.TrimSpace(record[3]) != "" {
+		weight, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+
+		if err != nil || weight <= 0 {
+			return OverlayEntry{}, fmt.Errorf("weight %q isn't a number more than 0", record[3])
+		}
+
+		entry.Weight = weight
+	}
+
+	return entry, nil
+}
+
+// Apply returns a new lexicon with the overlay's changes made to the base lexicon
+//
+// Could be used like
+//   merged := overlay.Apply(lexicon)
+func (o *Overlay) Apply(base *Lexicon) *Lexicon {
+	suppressed := make(map[string]bool)
+	boosts := make(map[string]OverlayEntry)
+	added := []LexiconEntry{}
+
+	for _, entry := range o.entries {
+		switch entry.Action {
+		case OverlaySuppress:
+			suppressed[wordKey(entry.Lemma, entry.PartOfSpeech)] = true
+		case OverlayBoost:
+			boosts[wordKey(entry.Lemma, entry.PartOfSpeech)] = entry
+		case OverlayAdd:
+			added = append(added, entry.LexiconEntry)
+		}
+	}
+
+	entries := []LexiconEntry{}
+	boosted := make(map[string]bool)
+
+	for _, entry := range append(base.Entries(), added...) {
+		key := wordKey(entry.Lemma, entry.PartOfSpeech)
+
+		if suppressed[key] || suppressed[wordKey(entry.Lemma, "")] {
+			continue
+		}
+
+		if boost, ok := boosts[key]; ok {
+			entry.Weight = entry.effectiveWeight() * boostFactor(boost)
+			boosted[key] = true
+		}
+
+		entries = append(entries, entry)
+	}
+
+	// Boosted words the base doesn't have are added, as likely as the boost makes them
+	for _, entry := range o.entries {
+		key := wordKey(entry.Lemma, entry.PartOfSpeech)
+
+		if entry.Action == OverlayBoost && !boosted[key] && !suppressed[key] {
+			entry.Weight = boostFactor(entry)
+			entries = append(entries, entry.LexiconEntry)
+			boosted[key] = true
+		}
+	}
+
+	lexicon := NewLexicon(entries...)
+	lexicon.sortPools()
+
+	return lexicon
+}
+
+// Lexicon returns the words the overlay adds or boosts, which are the ones drawn first
+//
+// Could be used like
+//   generator := mnemonic.NewLexiconWordGenerator(overlay.Lexicon(), "noun", random)
+func (o *Overlay) Lexicon() *Lexicon {
+	return o.Apply(NewLexicon())
+}
+
+// WithDomains returns an overlay that only adds or boosts words in the domains, see Lexicon.WithDomains
+//
+// A word the base lexicon has is in the domains its entries are in. Overlays don't say what domain a word is, so
+// the words only the overlay has are left out when the domains restrict their part of speech. Suppressing a word is
+// always kept.
+//
+// Could be used like
+//   restricted := overlay.WithDomains(lexicon, "noun.animal")
+func (o *Overlay) WithDomains(base *Lexicon, domains ...string) *Overlay {
+	if len(domains) == 0 {
+		return o
+	}
+
+	known := make(map[string]bool)
+	inBase := make(map[string]bool)
+
+	for _, entry := range base.Entries() {
+		key := wordKey(entry.Lemma, entry.PartOfSpeech)
+		known[key] = true
+		inBase[key] = inBase[key] || inDomains(entry, domains)
+	}
+
+	restricted := NewOverlay()
+
+	for _, entry := range o.entries {
+		key := wordKey(entry.Lemma, entry.PartOfSpeech)
+
+		switch {
+		case entry.Action == OverlaySuppress:
+		case known[key] && !inBase[key]:
+			continue
+		case !known[key] && !inDomains(entry.LexiconEntry, domains):
+			continue
+		}
+
+		restricted.entries = append(restricted.entries, entry)
+	}
+
+	return restricted
+}
+
+// boostFactor returns how many times more likely a boost makes a word
+func boostFactor(entry OverlayEntry) float64 {
+	if entry.Weight == 0 {
+		return defaultBoost
+	}
+
+	return entry.Weight
+}
@@ -0,0 +1,166 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Overlay", func() {
+	base := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "moist", PartOfSpeech: "adj"},
+		LexiconEntry{Lemma: "moist", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "mango", PartOfSpeech: "noun"},
+	)
+
+	Context("Reading", func() {
+		It("Reads actions, words, parts of speech and weights", func() {
+			overlay, err := ReadOverlay(strings.NewReader(
+				"action,word,pos,weight\nadd,gopher,noun\nboost,mango,noun,3\n# gone\nsuppress,moist\n",
+			), ',', "team")
+
+			Expect(err).NotTo(HaveOccurred())
+
+			merged := overlay.Apply(base)
+
+			Expect(merged.Pool("noun", "g")).To(Equal([]LexiconEntry{
+				{Lemma: "gopher", PartOfSpeech: "noun", Source: "team"},
+			}))
+			Expect(merged.Pool("noun", "m")).To(Equal([]LexiconEntry{
+				{Lemma: "mango", PartOfSpeech: "noun", Weight: 3},
+			}))
+			Expect(merged.Pool("adj", "m")).To(BeEmpty())
+		})
+		It("Rejects unknown actions", func() {
+			_, err := ReadOverlay(strings.NewReader("remove,mango,noun\n"), ',', "team")
+
+			Expect(err).To(MatchError(`row 1: action "remove" isn't one of add, boost or suppress`))
+		})
+		It("Needs a part of speech to add a word", func() {
+			_, err := ReadOverlay(strings.NewReader("add,gopher\n"), ',', "team")
+
+			Expect(err).To(MatchError("row 1: only suppress can leave out the part of speech"))
+		})
+	})
+	Context("Apply", func() {
+		It("Only suppresses the part of speech given", func() {
+			overlay := NewOverlay(OverlayEntry{
+				Action:       OverlaySuppress,
+				LexiconEntry: LexiconEntry{Lemma: "Moist", PartOfSpeech: "adj"},
+			})
+
+			merged := overlay.Apply(base)
+
+			Expect(merged.Pool("adj", "m")).To(BeEmpty())
+			Expect(merged.Pool("noun", "m")).To(HaveLen(2))
+		})
+		It("Doubles the weight of boosted words unless told otherwise", func() {
+			overlay := NewOverlay(OverlayEntry{
+				Action:       OverlayBoost,
+				LexiconEntry: LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+			})
+
+			Expect(overlay.Apply(base).Pool("noun", "a")[0].Weight).To(Equal(2.0))
+		})
+		It("Adds boosted words that aren't there", func() {
+			overlay := NewOverlay(OverlayEntry{
+				Action:       OverlayBoost,
+				LexiconEntry: LexiconEntry{Lemma: "kiwi", PartOfSpeech: "noun", Weight: 4},
+			})
+
+			Expect(overlay.Apply(base).Pool("noun", "k")).To(Equal([]LexiconEntry{
+				{Lemma: "kiwi", PartOfSpeech: "noun", Weight: 4},
+			}))
+		})
+		It("Leaves the base lexicon alone", func() {
+			overlay := NewOverlay(OverlayEntry{Action: OverlaySuppress, LexiconEntry: LexiconEntry{Lemma: "apple"}})
+
+			overlay.Apply(base)
+
+			Expect(base.Pool("noun", "a")).To(HaveLen(1))
+		})
+	})
+	It("Picks capitalised words", func() {
+		overlay, err := ReadOverlay(strings.NewReader("add,Kubernetes,noun\n"), ',', "team")
+		Expect(err).NotTo(HaveOccurred())
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"k",
+				WithLexicon(NewLexicon(LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"})),
+				WithOverlay(overlay, 1),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("Kubernetes."))
+		}
+	})
+	It("Has a lexicon of only the words it adds and boosts", func() {
+		overlay := NewOverlay(
+			OverlayEntry{Action: OverlayAdd, LexiconEntry: LexiconEntry{Lemma: "gopher", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlayBoost, LexiconEntry: LexiconEntry{Lemma: "mango", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlaySuppress, LexiconEntry: LexiconEntry{Lemma: "apple"}},
+		)
+
+		Expect(overlay.Lexicon().Entries()).To(Equal([]LexiconEntry{
+			{Lemma: "gopher", PartOfSpeech: "noun"},
+			{Lemma: "mango", PartOfSpeech: "noun", Weight: 2},
+		}))
+	})
+	It("Only adds and boosts words in the domains", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "gopher", PartOfSpeech: "noun", Domain: "noun.animal"},
+			LexiconEntry{Lemma: "mango", PartOfSpeech: "noun", Domain: "noun.food"},
+		)
+		overlay := NewOverlay(
+			OverlayEntry{Action: OverlayBoost, LexiconEntry: LexiconEntry{Lemma: "gopher", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlayBoost, LexiconEntry: LexiconEntry{Lemma: "mango", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlayAdd, LexiconEntry: LexiconEntry{Lemma: "kubernetes", PartOfSpeech: "noun"}},
+			OverlayEntry{Action: OverlayAdd, LexiconEntry: LexiconEntry{Lemma: "deploy", PartOfSpeech: "verb"}},
+			OverlayEntry{Action: OverlaySuppress, LexiconEntry: LexiconEntry{Lemma: "moist"}},
+		)
+
+		Expect(overlay.WithDomains(lexicon, "noun.animal").Lexicon().Entries()).To(Equal([]LexiconEntry{
+			{Lemma: "gopher", PartOfSpeech: "noun", Weight: 2},
+			{Lemma: "deploy", PartOfSpeech: "verb"},
+		}))
+		Expect(overlay.WithDomains(lexicon)).To(BeIdenticalTo(overlay))
+	})
+})
+
+func ExampleOverlay_Apply() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun"},
+	)
+	overlay, _ := ReadOverlay(strings.NewReader("add,acme,noun\nsuppress,avocado,noun\n"), ',', "team")
+
+	for _, entry := range overlay.Apply(lexicon).Pool("noun", "a") {
+		fmt.Println(entry.Lemma)
+	}
+	// Output:
+	// acme
+	// apple
+}
@@ -0,0 +1,180 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"fmt"
+	"math/bits"
+	"sort"
+)
+
+// plentifulPool is how many words a letter needs for a part of speech before having more doesn't make a better
+// layout, so a big dictionary still has the odd adverb
+const plentifulPool = 64
+
+// PoolSizer says how many words there are for a part of speech that begin with a letter, a Lexicon is one
+type PoolSizer interface {
+	PoolSize(partOfSpeech string, letter string) int
+}
+
+// PoolSize returns how many entries there are for a part of speech that begin with the letter
+func (l *Lexicon) PoolSize(partOfSpeech string, letter string) int {
+	return len(l.pools[partOfSpeech][letter])
+}
+
+// NoPlanError is returned when a grammar has no layout with a word for every letter
+type NoPlanError struct {
+	// Letter is the letter no part of speech in the grammar has a word for, empty if each letter has some
+	Letter string
+	// Position is where the letter is, counting from 1
+	Position int
+}
+
+// Error says which letter has no words, or that the letters can't all have words at once
+func (e *NoPlanError) Error() string {
+	if e.Letter == "" {
+		return "the grammar has no layout with a word for every letter"
+	}
+
+	return fmt.Sprintf("no part of speech in the grammar has a word for %q at position %d", e.Letter, e.Position)
+}
+
+// Plan lays the letters out using the grammar like Expand, but only puts a word where the pools have words for it
+//
+// Of the layouts with a word for every letter, it uses the ones where the letter with the fewest words to pick from
+// has as many as possible, so a letter like x isn't given an adverb when there are nouns for it. Pools are compared
+// by how many times bigger one is than another, so 3 words lose to 500 but 40 and 50 are as good as each other.
+// Once every letter has plenty of words the layout is picked like Expand would. Last resort alternatives, weighted
+// 0.001 or less, are only used when there's no layout with a word for every letter without them, however few words
+// it has to pick from. When there's no layout with a word for every letter it returns a NoPlanError.
+//
+// Might be used like this:
+//   template, err := grammar.Plan(letters, lexicon, mnemonic.NewSeededRandomSource(42))
+func (g *Grammar) Plan(letters []string, pools PoolSizer, random RandomSource) (*TemplateBase, error) {
+	if len(letters) == 0 {
+		return NewTemplateFromSlots(nil), nil
+	}
+
+	sizes, err := g.poolSizes(letters, pools)
+
+	if err != nil {
+		return nil, err
+	}
+
+	thresholds := planThresholds(sizes)
+	allowed := func(threshold int) func(int, string) bool {
+		return func(index int, partOfSpeech string) bool {
+			return sizes[index][partOfSpeech] >= threshold
+		}
+	}
+	avoidLastResorts := true
+	expansion := func(threshold int) *grammarExpansion {
+		expansion := newGrammarExpansion(g, letters, random, allowed(threshold))
+		expansion.avoidLastResorts = avoidLastResorts
+
+		return expansion
+	}
+	fits := func(threshold int) bool {
+		return expansion(threshold).ruleFits(g.start, 0, len(letters))
+	}
+
+	// Only fall back on last resorts, like sentences that are too short, when nothing else fits at all
+	if !fits(thresholds[0]) {
+		avoidLastResorts = false
+	}
+
+	if !fits(thresholds[0]) {
+		return nil, &NoPlanError{}
+	}
+
+	// The fewer words a layout needs at each letter the more layouts there are, so find the most it can need
+	best := sort.Search(len(thresholds), func(i int) bool { return !fits(thresholds[i]) }) - 1
+	symbols := expansion(thresholds[best]).expandRule(g.start, 0, len(letters))
+
+	return NewTemplateFromSlots(grammarSlots(symbols, letters)), nil
+}
+
+// poolSizes returns how many words each letter has for each part of speech in the grammar, see poolScore
+func (g *Grammar) poolSizes(letters []string, pools PoolSizer) ([]map[string]int, error) {
+	partsOfSpeech := g.partsOfSpeech()
+	sizes := make([]map[string]int, len(letters))
+
+	for i, letter := range letters {
+		sizes[i] = make(map[string]int)
+		found := false
+
+		for _, partOfSpeech := range partsOfSpeech {
+			score := poolScore(pools.PoolSize(partOfSpeech, letter))
+			sizes[i][partOfSpeech] = score
+			found = found || score > 0
+		}
+
+		if !found {
+			return nil, &NoPlanError{Letter: letter, Position: i + 1}
+		}
+	}
+
+	return sizes, nil
+}
+
+// poolScore returns how good a pool of size words is to pick from, counting in doublings up to plentifulPool
+//
+// None is 0, 1 is 1, 2 or 3 is 2, 4 to 7 is 3 and so on.
+func poolScore(size int) int {
+	if size > plentifulPool {
+		size = plentifulPool
+	}
+
+	return bits.Len(uint(size))
+}
+
+// partsOfSpeech returns the parts of speech the grammar has words for
+func (g *Grammar) partsOfSpeech() []string {
+	partsOfSpeech := []string{}
+	seen := make(map[string]bool)
+
+	for _, alternatives := range g.rules {
+		for _, alternative := range alternatives {
+			for _, symbol := range alternative.symbols {
+				if symbol.kind == symbolWord && !seen[symbol.text] {
+					seen[symbol.text] = true
+					partsOfSpeech = append(partsOfSpeech, symbol.text)
+				}
+			}
+		}
+	}
+
+	return partsOfSpeech
+}
+
+// planThresholds returns the different numbers of words there are to pick from, smallest first, leaving out none
+func planThresholds(sizes []map[string]int) []int {
+	thresholds := []int{}
+	seen := make(map[int]bool)
+
+	for _, letterSizes := range sizes {
+		for _, size := range letterSizes {
+			if size > 0 && !seen[size] {
+				seen[size] = true
+				thresholds = append(thresholds, size)
+			}
+		}
+	}
+
+	sort.Ints(thresholds)
+
+	return thresholds
+}
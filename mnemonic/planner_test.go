@@ -0,0 +1,183 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// planGrammar is a noun and a verb, either way round
+const planGrammar = `mnemonic = noun verb . | verb noun .`
+
+// planLexicon has plenty of verbs for a but only a single noun, and it's the other way round for x
+var planLexicon = NewLexicon(
+	LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+	LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+	LexiconEntry{Lemma: "ask", PartOfSpeech: "verb"},
+	LexiconEntry{Lemma: "x-ray", PartOfSpeech: "noun"},
+	LexiconEntry{Lemma: "xylophone", PartOfSpeech: "noun"},
+	LexiconEntry{Lemma: "xerox", PartOfSpeech: "verb"},
+)
+
+var _ = Describe("Planner", func() {
+	It("Only puts a word where the letter has words for it", func() {
+		grammar, err := ReadGrammar(strings.NewReader(planGrammar))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+			LexiconEntry{Lemma: "xylophone", PartOfSpeech: "noun"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := grammar.Plan([]string{"a", "x"}, lexicon, NewSeededRandomSource(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.GetTemplate()).To(Equal(`{{ .Param1 | verb }} {{ .Param2 | noun }}.`))
+		}
+	})
+	It("Picks the layout with the most words to choose from for every letter", func() {
+		grammar, err := ReadGrammar(strings.NewReader(planGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := grammar.Plan([]string{"a", "x"}, planLexicon, NewSeededRandomSource(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.GetTemplate()).To(Equal(`{{ .Param1 | verb }} {{ .Param2 | noun }}.`))
+		}
+	})
+	It("Prefers a big pool to a small one even when both have a few words", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun . | adv .`))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "boldly", PartOfSpeech: "adv"},
+			LexiconEntry{Lemma: "briskly", PartOfSpeech: "adv"},
+			LexiconEntry{Lemma: "brightly", PartOfSpeech: "adv"},
+		)
+
+		for i := 0; i < 50; i++ {
+			lexicon.Add(LexiconEntry{Lemma: fmt.Sprintf("b%d", i), PartOfSpeech: "noun"})
+		}
+
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := grammar.Plan([]string{"b"}, lexicon, NewSeededRandomSource(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.GetTemplate()).To(Equal(`{{ .Param1 | noun }}.`))
+		}
+	})
+	It("Picks between layouts by weight when every letter has plenty of words", func() {
+		grammar, err := ReadGrammar(strings.NewReader(planGrammar))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon()
+
+		for i := 0; i < 100; i++ {
+			lexicon.Add(
+				LexiconEntry{Lemma: fmt.Sprintf("a%d", i), PartOfSpeech: "noun"},
+				LexiconEntry{Lemma: fmt.Sprintf("a%d", i), PartOfSpeech: "verb"},
+			)
+		}
+
+		layouts := make(map[string]bool)
+
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := grammar.Plan([]string{"a", "a"}, lexicon, NewSeededRandomSource(seed))
+
+			Expect(err).NotTo(HaveOccurred())
+			layouts[actual.GetTemplate()] = true
+		}
+
+		Expect(layouts).To(HaveLen(2))
+	})
+	It("Says which letter has no words", func() {
+		grammar, err := ReadGrammar(strings.NewReader(planGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = grammar.Plan([]string{"a", "q"}, planLexicon, NewSeededRandomSource(42))
+
+		Expect(err).To(Equal(&NoPlanError{Letter: "q", Position: 2}))
+		Expect(err.Error()).To(Equal(`no part of speech in the grammar has a word for "q" at position 2`))
+	})
+	It("Says when every letter has words but no layout fits them", func() {
+		grammar, err := ReadGrammar(strings.NewReader(`mnemonic = noun verb .`))
+		Expect(err).NotTo(HaveOccurred())
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "amble", PartOfSpeech: "verb"},
+			LexiconEntry{Lemma: "xylophone", PartOfSpeech: "noun"},
+		)
+
+		_, err = grammar.Plan([]string{"a", "x"}, lexicon, NewSeededRandomSource(42))
+
+		Expect(err).To(Equal(&NoPlanError{}))
+		Expect(err.Error()).To(Equal("the grammar has no layout with a word for every letter"))
+	})
+	It("Plans around the dictionary when generating", func() {
+		grammar, err := ReadGrammar(strings.NewReader(planGrammar))
+		Expect(err).NotTo(HaveOccurred())
+
+		actual, err := Generate(
+			context.Background(),
+			"ax",
+			WithLexicon(planLexicon),
+			WithGrammar(grammar),
+			WithSeed(42),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Words[0].PartOfSpeech).To(Equal("verb"))
+		Expect(actual.Words[1].PartOfSpeech).To(Equal("noun"))
+	})
+	It("Still says which letter has no word when there's no plan", func() {
+		_, err := Generate(context.Background(), "aq", WithLexicon(planLexicon), WithSeed(42))
+
+		Expect(err).To(BeAssignableToTypeOf(&NoWordError{}))
+	})
+})
+
+func ExampleGrammar_Plan() {
+	grammar, err := ReadGrammar(strings.NewReader(`mnemonic = adj noun | noun verb`))
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "quick", PartOfSpeech: "adj"},
+		LexiconEntry{Lemma: "queen", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "quilt", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "zebra", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "zip", PartOfSpeech: "verb"},
+		LexiconEntry{Lemma: "zoom", PartOfSpeech: "verb"},
+	)
+
+	template, err := grammar.Plan([]string{"q", "z"}, lexicon, NewSeededRandomSource(42))
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	fmt.Println(template.GetTemplate())
+	// Output: {{ .Param1 | noun }} {{ .Param2 | verb }}
+}
@@ -0,0 +1,60 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import "io"
+
+// Mnemonic is a generated mnemonic, the words in it and the text they make
+type Mnemonic struct {
+	Words []ChosenWord
+	// Seed is the seed the words were picked with, when it's known
+	Seed     int64
+	text     string
+	escaping Escaping
+}
+
+// ChosenWord is a word in a mnemonic, with the letter of the input it stands for
+type ChosenWord struct {
+	Word
+	// Letter is the letter of the input the word stands for
+	Letter string
+	// Index is where the letter is in the input, starting at 0
+	Index int
+	// Sentence is the sentence the word is in, starting at 0
+	Sentence int
+	// Generator is the function name of the generator that picked the word, empty for a placeholder
+	Generator string
+}
+
+// Text returns the mnemonic as plain text
+func (m *Mnemonic) Text() string {
+	return m.text
+}
+
+// String returns the mnemonic in its output format, which is plain text unless Generate was told otherwise
+func (m *Mnemonic) String() string {
+	return m.escaping.Escape(m.text)
+}
+
+// Render writes the mnemonic's text with the given escaping
+//
+// Could be used like
+//   err := result.Render(os.Stdout, mnemonic.EscapeHTML)
+func (m *Mnemonic) Render(writer io.Writer, escaping Escaping) error {
+	_, err := io.WriteString(writer, escaping.Escape(m.text))
+
+	return err
+}
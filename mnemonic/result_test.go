@@ -0,0 +1,82 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Mnemonic", func() {
+	Context("Rendering", func() {
+		It("Renders with escaping", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("jack-o'-lantern", "noun"))
+			result, err := parser.Generate(context.Background(), NewTemplate([]string{"j"}), []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			actual := &bytes.Buffer{}
+			err = result.Render(actual, EscapeHTML)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("jack-o&#39;-lantern."))
+		})
+		It("Is plain text as a string", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("jack-o'-lantern", "noun"))
+			result, err := parser.Generate(context.Background(), NewTemplate([]string{"j"}), []string{})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(result.String()).To(Equal("jack-o'-lantern."))
+			Expect(result.Text()).To(Equal("jack-o'-lantern."))
+		})
+	})
+})
+
+func ExampleMnemonic_Render() {
+	letters := strings.Split("demo", "")
+	generator := NewTemplateParser(
+		NewStaticWordGenerator("dancing", "adj"),
+		NewStaticWordGenerator("eggs", "noun"),
+		NewStaticWordGenerator("move", "verb"),
+		NewStaticWordGenerator("outward", "adv"),
+	)
+
+	result, err := generator.Generate(context.Background(), NewTemplate(letters), letters)
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	for _, word := range result.Words {
+		fmt.Println(word.Letter, word.Lemma, word.PartOfSpeech)
+	}
+
+	_ = result.Render(os.Stdout, EscapeJSON)
+	// Output:
+	// d dancing adj
+	// e eggs noun
+	// m move verb
+	// o outward adv
+	// "dancing eggs move outward."
+}
@@ -18,6 +18,8 @@ package mnemonic
 import (
 	"fmt"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/lloyd/wnram"
 )
@@ -25,123 +27,161 @@ import (
 // parameterPrefix is the prefix to give elements in the template
 const parameterPrefix = "Param"
 
+// titleFunc is the template function that capitalises the first letter of a word
+const titleFunc = "title"
+
 // Template to be used to generate the mnemonic
 type Template interface {
 	GetTemplate() string
 	GetParameters() map[string]string
 	GetUsedFunctions() []string
+	GetSlots() []Slot
+}
+
+// Slot is a single word in a template, standing in for one letter of the input
+type Slot struct {
+	// Letter is the letter of the input the word has to begin with
+	Letter string
+	// Index is where the letter is in the input, starting at 0
+	Index int
+	// PartOfSpeech is the name of the function that generates the word
+	PartOfSpeech string
+	// Sentence is the sentence the slot is in, starting at 0
+	Sentence int
+	// EndsSentence is true for the last slot in a sentence
+	EndsSentence bool
+	// Literal is text used as it is in place of a word, it doesn't stand for a letter so its Index is -1
+	Literal string
+	// Ending is any punctuation straight after the word, a sentence ends with a full stop unless it's ? or !
+	Ending string
+	// EndsLine is true when the next slot starts a new line
+	EndsLine bool
+	// Title is true when the word starts with a capital letter
+	Title bool
 }
 
 // TemplateBase is a template to generate a mnemonic
 type TemplateBase struct {
-	template      string
-	parameters    map[string]string
-	usedFunctions []string
+	slots []Slot
 }
 
-// NewTemplate returns a template to generate a mnemonic
+// NewTemplate returns a template to generate a mnemonic, laid out by the default grammar
+//
+// See DefaultGrammar, use Grammar.Expand to lay the letters out some other way.
 //
 // Might be used like this:
 //   template := mnemonic.NewTemplate([]string{"e", "x", "a", "m", "p", "l", "e"})
 func NewTemplate(letters []string) *TemplateBase {
-	templateFragments := []string{}
-	letterCount := len(letters)
+	// The default grammar only has one layout for each number of letters, so there's nothing to pick at random
+	return newStyleTemplate(StyleSentence, letters)
+}
+
+// NewTemplateFromSlots returns a template made up of the given slots, in order
+//
+// Might be used like this:
+//   slots := mnemonic.NewTemplate(letters).GetSlots()
+//   slots[0].PartOfSpeech = "noun"
+//   template := mnemonic.NewTemplateFromSlots(slots)
+func NewTemplateFromSlots(slots []Slot) *TemplateBase {
+	return &TemplateBase{slots: append([]Slot{}, slots...)}
+}
 
-	for i := 0; i < letterCount/4; i++ {
-		templateFragments = append(templateFragments, generateUpTo4Template(4, i*4))
+// availableFunctions returns all the functions available to use, sorted in order of preference
+func availableFunctions() []string {
+	return []string{
+		wnram.Adjective.String(),
+		wnram.Noun.String(),
+		wnram.Verb.String(),
+		wnram.Adverb.String(),
 	}
+}
+
+// GetTemplate returns a template string compatible with the go template engine
+func (t TemplateBase) GetTemplate() string {
+	template := ""
+
+	for i, slot := range t.slots {
+		fragment := fmt.Sprintf("{{ .%s%d | %s }}", parameterPrefix, slot.Index+1, slot.PartOfSpeech)
+
+		if slot.Title {
+			fragment = fmt.Sprintf("{{ .%s%d | %s | %s }}", parameterPrefix, slot.Index+1, slot.PartOfSpeech, titleFunc)
+		}
+
+		if slot.Literal != "" {
+			fragment = fmt.Sprintf("{{ %q }}", slot.Literal)
+		}
+
+		fragment += slot.Ending
+
+		if slot.EndsSentence && !strings.HasSuffix(slot.Ending, "?") && !strings.HasSuffix(slot.Ending, "!") {
+			fragment += "."
+		}
 
-	templateFragments = append(templateFragments, generateUpTo4Template(letterCount%4, 4*(letterCount/4)))
+		if i > 0 && t.slots[i-1].EndsLine {
+			template += "\n"
+		} else if i > 0 {
+			template += " "
+		}
 
-	return &TemplateBase{
-		usedFunctions: newUsedFunctions(letters),
-		parameters:    newParameterMap(letters),
-		template:      strings.Trim(strings.Join(templateFragments, " "), " "),
+		template += fragment
 	}
+
+	return template
 }
 
-// newParameterMap turns a list of letters into parameters to use in the template
-func newParameterMap(parameters []string) map[string]string {
+// GetParameters returns a map with the parameters for this template in
+func (t TemplateBase) GetParameters() map[string]string {
 	parameterMap := make(map[string]string)
 
-	for i := range parameters {
-		parameterMap[fmt.Sprintf("%s%d", parameterPrefix, i+1)] = parameters[i]
+	for _, slot := range letterSlots(t.slots) {
+		parameterMap[fmt.Sprintf("%s%d", parameterPrefix, slot.Index+1)] = slot.Letter
 	}
 
 	return parameterMap
 }
 
-// newUsedFunctions returns the parameters used in this template
-func newUsedFunctions(parameters []string) []string {
+// GetUsedFunctions returns the used functions, in the order they first appear
+func (t TemplateBase) GetUsedFunctions() []string {
 	usedFunctions := []string{}
-	availableFunc := availableFunctions()
-
-	switch len(parameters) {
-	case 0:
-	case 1:
-		usedFunctions = append(usedFunctions, availableFunc[1])
-	case 2:
-		usedFunctions = append(usedFunctions, availableFunc[:2]...)
-	case 3:
-		usedFunctions = append(usedFunctions, availableFunc[:3]...)
-	default:
-		usedFunctions = append(usedFunctions, availableFunc...)
+	seen := make(map[string]bool)
+
+	for _, slot := range letterSlots(t.slots) {
+		if seen[slot.PartOfSpeech] {
+			continue
+		}
+
+		seen[slot.PartOfSpeech] = true
+		usedFunctions = append(usedFunctions, slot.PartOfSpeech)
 	}
 
 	return usedFunctions
 }
 
-// availableFunctions returns all the functions available to use, sorted in order of preference
-func availableFunctions() []string {
-	return []string{
-		wnram.Adjective.String(),
-		wnram.Noun.String(),
-		wnram.Verb.String(),
-		wnram.Adverb.String(),
-	}
+// GetSlots returns the slots that make up this template, in order
+func (t TemplateBase) GetSlots() []Slot {
+	return append([]Slot{}, t.slots...)
 }
 
-// generateUpTo4Template returns a template for up to 4 parameters, you can offset the parameter number too
-func generateUpTo4Template(length int, paramOffset int) string {
-	availableFunc := availableFunctions()
-
-	switch length {
-	case 0:
-		return ""
-	case 1:
-		return fmt.Sprintf("{{ .%s%d | %s }}.", parameterPrefix, paramOffset+1, availableFunc[1])
-	default:
-		placeholderFragments := []string{}
-
-		for i := 0; i < length; i++ {
-			placeholderFragments = append(
-				placeholderFragments,
-				fmt.Sprintf(
-					"{{ .%s%d | %s }}",
-					parameterPrefix,
-					paramOffset+i+1,
-					availableFunc[i],
-				),
-			)
-		}
-
-		template := strings.Join(placeholderFragments, " ")
+// letterSlots returns the slots that stand for a letter, leaving out literal text
+func letterSlots(slots []Slot) []Slot {
+	letters := []Slot{}
 
-		return fmt.Sprintf("%s.", template)
+	for _, slot := range slots {
+		if slot.Literal == "" {
+			letters = append(letters, slot)
+		}
 	}
-}
 
-// GetTemplate returns a template string compatible with the go template engine
-func (t TemplateBase) GetTemplate() string {
-	return t.template
+	return letters
 }
 
-// GetParameters returns a map with the parameters for this template in
-func (t TemplateBase) GetParameters() map[string]string {
-	return t.parameters
-}
+// capitalise upper cases the first letter of the text, leaving the rest of it as it is
+func capitalise(text string) string {
+	first, size := utf8.DecodeRuneInString(text)
 
-// GetUsedFunctions returns the used functions
-func (t TemplateBase) GetUsedFunctions() []string {
-	return t.usedFunctions
+	if size == 0 {
+		return text
+	}
+
+	return string(unicode.ToUpper(first)) + text[size:]
 }
@@ -16,66 +16,365 @@
 package mnemonic
 
 import (
-	"bufio"
-	"html/template"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"text/template"
 )
 
+// alternativeAttempts is how many times per requested alternative we'll try to find a new mnemonic
+const alternativeAttempts = 10
+
 // TemplateParser interface returned by the NewTemplateParses
 type TemplateParser interface {
-	Parse(userTemplate string, input []string, writer *bufio.Writer) error
+	Generate(ctx context.Context, userTemplate Template, input []string) (*Mnemonic, error)
+	GenerateAlternatives(ctx context.Context, userTemplate Template, input []string, count int) ([]*Mnemonic, error)
+	Parse(ctx context.Context, userTemplate Template, input []string, writer io.Writer, escaping Escaping) error
+	ParseAlternatives(
+		ctx context.Context,
+		userTemplate Template,
+		input []string,
+		count int,
+		escaping Escaping,
+	) ([]string, error)
+}
+
+// Make sure TemplateParserBase keeps up with the interface
+var _ TemplateParser = (*TemplateParserBase)(nil)
+
+// NoWordError is returned when no generator has a word for a letter in the input
+type NoWordError struct {
+	Letter string
+	// Position is where the letter is in the input, counting from 1
+	Position int
+}
+
+// Error describes which letter couldn't be turned into a word
+func (e *NoWordError) Error() string {
+	return fmt.Sprintf("no word found for %q at position %d", e.Letter, e.Position)
 }
 
 // TemplateParserBase is a parser that can convert a template into a string
 type TemplateParserBase struct {
-	funcMap template.FuncMap
+	generators  []WordGeneratorV2
+	placeholder string
+}
+
+// noGenerator is used in place of a generator's position for template functions no generator has
+const noGenerator = -1
+
+// placeholderSource is the source given to placeholders used when there's no word for a letter
+const placeholderSource = "placeholder"
+
+// execution tracks where we are in the template while it's being executed
+type execution struct {
+	ctx   context.Context
+	slots []Slot
+	words []ChosenWord
+	err   error
 }
 
 // NewTemplateParser returns a new parser that can convert a template into a string
 //
+// When a generator has no word for a letter the other generators are tried in order, then the placeholder if one
+// is set, otherwise parsing fails with a NoWordError.
+//
 // Might be used like this
+//  lexicon := mnemonic.NewWordNetLexicon(wn)
 //  generator := mnemonic.NewTemplateParser(
-//    mnemonic.NewWnramWordGenerator(wn, wnram.Adjective),
-//    mnemonic.NewWnramWordGenerator(wn, wnram.Noun),
-//    mnemonic.NewWnramWordGenerator(wn, wnram.Verb),
-//    mnemonic.NewWnramWordGenerator(wn, wnram.Adverb),
+//    mnemonic.NewLexiconWordGenerator(lexicon, "adj", random),
+//    mnemonic.NewLexiconWordGenerator(lexicon, "noun", random),
+//    mnemonic.NewLexiconWordGenerator(lexicon, "verb", random),
+//    mnemonic.NewLexiconWordGenerator(lexicon, "adv", random),
 //  )
 func NewTemplateParser(
 	generator ...WordGenerator,
 ) *TemplateParserBase {
-	funcMap := template.FuncMap{}
+	generators := []WordGeneratorV2{}
 
 	for i := range generator {
-		funcMap[generator[i].GetFuncName()] = generator[i].Generate
+		if native, ok := generator[i].(WordGeneratorV2); ok {
+			generators = append(generators, native)
+			continue
+		}
+
+		generators = append(generators, NewWordGeneratorAdapter(generator[i]))
 	}
 
+	return NewTemplateParserV2(generators...)
+}
+
+// NewTemplateParserV2 returns a new parser that gets its words from WordGeneratorV2s
+//
+// Might be used like this
+//  generator := mnemonic.NewTemplateParserV2(
+//    mnemonic.NewWordGeneratorAdapter(mnemonic.NewStaticWordGenerator("dancing", "adj")),
+//  )
+func NewTemplateParserV2(
+	generator ...WordGeneratorV2,
+) *TemplateParserBase {
 	return &TemplateParserBase{
-		funcMap: funcMap,
+		generators: generator,
 	}
 }
 
+// SetPlaceholder sets the text used for a letter when no generator has a word for it
+//
+// Might be used like this
+//   generator.SetPlaceholder("?")
+func (g *TemplateParserBase) SetPlaceholder(placeholder string) {
+	g.placeholder = placeholder
+}
+
+// Generate returns a mnemonic for the input, with each word that was picked and the letter it stands for
+//
+// The input is the letters to fill the template with, so a template can be reused for any input with the same
+// number of letters. If the input is empty the letters the template was made with are used.
+//
+// Errors from the generators, including the context being cancelled, are returned as they are.
+//
+// Might be used like this
+//   result, err := generator.Generate(ctx, template, letters)
+//   fmt.Println(result.Text())
+func (g *TemplateParserBase) Generate(ctx context.Context, userTemplate Template, input []string) (*Mnemonic, error) {
+	alternatives, err := g.GenerateAlternatives(ctx, userTemplate, input, 1)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return alternatives[0], nil
+}
+
+// GenerateAlternatives returns up to count different mnemonics from the same template
+//
+// The template is only compiled once, and the generators are reused for every alternative. Fewer than count
+// are returned when the dictionary can't produce that many different mnemonics, but there's always at least one.
+//
+// Might be used like this
+//   alternatives, err := generator.GenerateAlternatives(ctx, template, letters, 5)
+func (g *TemplateParserBase) GenerateAlternatives(
+	ctx context.Context,
+	userTemplate Template,
+	input []string,
+	count int,
+) ([]*Mnemonic, error) {
+	parameters, err := templateParameters(userTemplate, input)
+
+	if err != nil {
+		return nil, err
+	}
+
+	templateParsed, state, err := g.compile(ctx, userTemplate)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if count < 1 {
+		count = 1
+	}
+
+	alternatives := []*Mnemonic{}
+	seen := make(map[string]bool)
+
+	for attempt := 0; attempt < count*alternativeAttempts && len(alternatives) < count; attempt++ {
+		alternative, err := g.execute(templateParsed, state, parameters)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[alternative.Text()] {
+			continue
+		}
+
+		seen[alternative.Text()] = true
+		alternatives = append(alternatives, alternative)
+	}
+
+	return alternatives, nil
+}
+
 // Parse returns the parted template
 //
+// It's the same as Generate, but only writes the text of the mnemonic. The words are written as plain text unless
+// another escaping is asked for.
+//
 // Might be used like this
-//   err = generator.Parse(template, letters, bufio.NewWriter(os.Stdout))
+//   err = generator.Parse(ctx, template, letters, os.Stdout, mnemonic.EscapePlain)
 // Or you can capture the string
 //   buffer := &bytes.Buffer{}
-//   writer := bufio.NewWriter(buffer)
-//   _ = generator.Parse(template, letters, writer)
+//   _ = generator.Parse(ctx, template, letters, buffer, mnemonic.EscapeHTML)
 //   fmt.Println(buffer.String())
-func (g *TemplateParserBase) Parse(userTemplate Template, input []string, writer *bufio.Writer) error {
-	htmlTemplate := template.New("generator").Funcs(g.funcMap)
-	templateParsed, err := htmlTemplate.Parse(userTemplate.GetTemplate())
+func (g *TemplateParserBase) Parse(
+	ctx context.Context,
+	userTemplate Template,
+	input []string,
+	writer io.Writer,
+	escaping Escaping,
+) error {
+	result, err := g.Generate(ctx, userTemplate, input)
 
 	if err != nil {
 		return err
 	}
 
-	err = templateParsed.Execute(writer, userTemplate.GetParameters())
-	writer.Flush()
+	return result.Render(writer, escaping)
+}
+
+// ParseAlternatives returns up to count different mnemonics from the same template, as text
+//
+// Might be used like this
+//   alternatives, err := generator.ParseAlternatives(ctx, template, letters, 5, mnemonic.EscapePlain)
+func (g *TemplateParserBase) ParseAlternatives(
+	ctx context.Context,
+	userTemplate Template,
+	input []string,
+	count int,
+	escaping Escaping,
+) ([]string, error) {
+	results, err := g.GenerateAlternatives(ctx, userTemplate, input, count)
 
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	alternatives := []string{}
+
+	for i := range results {
+		alternatives = append(alternatives, escaping.Escape(results[i].Text()))
+	}
+
+	return alternatives, nil
+}
+
+// templateParameters returns the template's parameters, with the letters from the input in place of its own
+func templateParameters(userTemplate Template, input []string) (map[string]string, error) {
+	parameters := make(map[string]string)
+
+	for key, value := range userTemplate.GetParameters() {
+		parameters[key] = value
+	}
+
+	if len(input) == 0 {
+		return parameters, nil
+	}
+
+	slots := letterSlots(userTemplate.GetSlots())
+
+	if len(slots) > 0 && len(slots) != len(input) {
+		return nil, fmt.Errorf("template has %d letters but the input has %d", len(slots), len(input))
+	}
+
+	for i := range input {
+		parameters[fmt.Sprintf("%s%d", parameterPrefix, i+1)] = input[i]
+	}
+
+	return parameters, nil
+}
+
+// compile turns a template into something that can be executed
+func (g *TemplateParserBase) compile(
+	ctx context.Context,
+	userTemplate Template,
+) (*template.Template, *execution, error) {
+	state := &execution{ctx: ctx, slots: letterSlots(userTemplate.GetSlots())}
+	funcMap := template.FuncMap{titleFunc: capitalise}
+
+	for i := range g.generators {
+		funcMap[g.generators[i].GetFuncName()] = g.wordFunc(i, state)
+	}
+
+	// A dictionary might not have every part of speech, so those words come from whichever generator has one
+	for _, name := range userTemplate.GetUsedFunctions() {
+		if _, ok := funcMap[name]; !ok {
+			funcMap[name] = g.wordFunc(noGenerator, state)
+		}
+	}
+
+	templateParsed, err := template.New("generator").Funcs(funcMap).Parse(userTemplate.GetTemplate())
+
+	return templateParsed, state, err
+}
+
+// execute runs a compiled template, returning the generator's error rather than the template engine's wrapper
+func (g *TemplateParserBase) execute(
+	templateParsed *template.Template,
+	state *execution,
+	parameters map[string]string,
+) (*Mnemonic, error) {
+	state.words = []ChosenWord{}
+	state.err = nil
+
+	buffer := &bytes.Buffer{}
+	err := templateParsed.Execute(buffer, parameters)
+
+	if state.err != nil {
+		return nil, state.err
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Mnemonic{Words: state.words, text: buffer.String()}, nil
+}
+
+// wordFunc returns the template function for a generator, falling back when it has no word or there's no generator
+func (g *TemplateParserBase) wordFunc(generator int, state *execution) func(string) (string, error) {
+	return func(letter string) (string, error) {
+		chosen := state.nextWord(letter)
+		word, err := Word{}, ErrNoWord
+
+		if generator != noGenerator {
+			word, err = g.generators[generator].GenerateWord(state.ctx, letter)
+			chosen.Generator = g.generators[generator].GetFuncName()
+		}
+
+		for i := 0; err == ErrNoWord && i < len(g.generators); i++ {
+			if i == generator {
+				continue
+			}
+
+			word, err = g.generators[i].GenerateWord(state.ctx, letter)
+			chosen.Generator = g.generators[i].GetFuncName()
+		}
+
+		if err != nil && err != ErrNoWord {
+			state.err = err
+
+			return "", err
+		}
+
+		if err == ErrNoWord && g.placeholder == "" {
+			state.err = &NoWordError{Letter: letter, Position: chosen.Index + 1}
+
+			return "", state.err
+		}
+
+		if err == ErrNoWord {
+			word = Word{Lemma: g.placeholder, Source: placeholderSource}
+			chosen.Generator = ""
+		}
+
+		chosen.Word = word
+		state.words = append(state.words, chosen)
+
+		return word.String(), nil
+	}
+}
+
+// nextWord returns what we know about the next word from the template's slots
+func (e *execution) nextWord(letter string) ChosenWord {
+	position := len(e.words)
+	chosen := ChosenWord{Letter: letter, Index: position}
+
+	if position < len(e.slots) {
+		chosen.Index = e.slots[position].Index
+		chosen.Sentence = e.slots[position].Sentence
 	}
 
-	return nil
+	return chosen
 }
@@ -16,8 +16,9 @@
 package mnemonic_test
 
 import (
-	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"strings"
@@ -31,6 +32,7 @@ type testTemplate struct {
 	template      string
 	parameters    map[string]string
 	usedFunctions []string
+	slots         []Slot
 }
 
 // returns a template string compatible with the go template engine
@@ -48,6 +50,11 @@ func (t testTemplate) GetUsedFunctions() []string {
 	return t.usedFunctions
 }
 
+// returns the slots
+func (t testTemplate) GetSlots() []Slot {
+	return t.slots
+}
+
 type testWordGenerator struct {
 	funcName string
 	function func(letter string) string
@@ -61,24 +68,35 @@ func (w *testWordGenerator) GetFuncName() string {
 	return w.funcName
 }
 
+type testWordGeneratorV2 struct {
+	funcName string
+	function func(ctx context.Context, letter string) (Word, error)
+}
+
+func (w *testWordGeneratorV2) GenerateWord(ctx context.Context, letter string) (Word, error) {
+	return w.function(ctx, letter)
+}
+
+func (w *testWordGeneratorV2) GetFuncName() string {
+	return w.funcName
+}
+
 var _ = Describe("TemplateParser", func() {
 	Context("Generating", func() {
 		It("Returns without parameters or arguments", func() {
 			parser := NewTemplateParser()
 			actual := &bytes.Buffer{}
-			writer := bufio.NewWriter(actual)
 
 			template := testTemplate{
 				template: "Testing",
 			}
 
-			parser.Parse(template, []string{}, writer)
+			parser.Parse(context.Background(), template, []string{}, actual, EscapePlain)
 			Expect(actual.String()).To(Equal("Testing"))
 		})
 		It("You can set parameters", func() {
 			parser := NewTemplateParser()
 			actual := &bytes.Buffer{}
-			writer := bufio.NewWriter(actual)
 
 			parameters := make(map[string]string)
 			parameters["Param1"] = "a"
@@ -89,7 +107,7 @@ var _ = Describe("TemplateParser", func() {
 				parameters: parameters,
 			}
 
-			parser.Parse(template, []string{}, writer)
+			parser.Parse(context.Background(), template, []string{}, actual, EscapePlain)
 			Expect(actual.String()).To(Equal("a b"))
 		})
 		It("You can set use methods", func() {
@@ -98,7 +116,6 @@ var _ = Describe("TemplateParser", func() {
 				function: strings.ToUpper,
 			})
 			actual := &bytes.Buffer{}
-			writer := bufio.NewWriter(actual)
 
 			parameters := make(map[string]string)
 
@@ -108,7 +125,7 @@ var _ = Describe("TemplateParser", func() {
 				usedFunctions: []string{"upper"},
 			}
 
-			parser.Parse(template, []string{}, writer)
+			parser.Parse(context.Background(), template, []string{}, actual, EscapePlain)
 			Expect(actual.String()).To(Equal("TESTING"))
 		})
 		It("You can set parameters and methods", func() {
@@ -117,7 +134,6 @@ var _ = Describe("TemplateParser", func() {
 				function: strings.ToUpper,
 			})
 			actual := &bytes.Buffer{}
-			writer := bufio.NewWriter(actual)
 
 			parameters := make(map[string]string)
 			parameters["Param1"] = "a"
@@ -128,10 +144,375 @@ var _ = Describe("TemplateParser", func() {
 				parameters: parameters,
 			}
 
-			parser.Parse(template, []string{}, writer)
+			parser.Parse(context.Background(), template, []string{}, actual, EscapePlain)
 			Expect(actual.String()).To(Equal("A B"))
 		})
 	})
+	Context("Input", func() {
+		It("Fills the template with the letters from the input", func() {
+			parser := NewTemplateParser(&testWordGenerator{
+				funcName: "upper",
+				function: strings.ToUpper,
+			})
+			actual := &bytes.Buffer{}
+
+			template := testTemplate{
+				template:   "{{ .Param1 | upper }} {{ .Param2 | upper }}",
+				parameters: map[string]string{"Param1": "a", "Param2": "b"},
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"x", "y"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("X Y"))
+		})
+		It("Reuses a template for a different input of the same length", func() {
+			parser := NewTemplateParser(
+				&testWordGenerator{funcName: "adj", function: strings.ToUpper},
+				&testWordGenerator{funcName: "noun", function: strings.ToUpper},
+			)
+			actual := &bytes.Buffer{}
+
+			err := parser.Parse(context.Background(), NewTemplate([]string{"a", "b"}), []string{"x", "y"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("X Y."))
+		})
+		It("Errors when the input is a different length to the template", func() {
+			parser := NewTemplateParser()
+			actual := &bytes.Buffer{}
+
+			err := parser.Parse(context.Background(), NewTemplate([]string{"a", "b"}), []string{"x"}, actual, EscapePlain)
+
+			Expect(err).To(MatchError("template has 2 letters but the input has 1"))
+		})
+	})
+	Context("Escaping", func() {
+		It("Writes words as plain text by default", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("jack-o'-lantern", "noun"))
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "j"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }} & co",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"j"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("jack-o'-lantern & co"))
+		})
+		It("Escapes when asked to", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("jack-o'-lantern", "noun"))
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "j"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }} & co",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"j"}, actual, EscapeHTML)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("jack-o&#39;-lantern &amp; co"))
+		})
+		It("Escapes each alternative", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("jack-o'-lantern", "noun"))
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "j"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			actual, err := parser.ParseAlternatives(context.Background(), template, []string{"j"}, 1, EscapeShell)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal([]string{`'jack-o'\''-lantern'`}))
+		})
+	})
+	Context("Missing words", func() {
+		It("Falls back to another generator", func() {
+			parser := NewTemplateParser(
+				NewStaticWordGenerator("", "adv"),
+				NewStaticWordGenerator("apple", "noun"),
+			)
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | adv }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("apple"))
+		})
+		It("Uses another generator when there's none for the part of speech", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("apple", "noun"))
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:      "{{ .Param1 | adj }}",
+				parameters:    parameters,
+				usedFunctions: []string{"adj"},
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("apple"))
+		})
+		It("Uses the placeholder when no generator has a word", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("", "noun"))
+			parser.SetPlaceholder("?")
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+			parameters["Param2"] = "7"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }} {{ .Param2 | noun }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a", "7"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("? ?"))
+		})
+		It("Returns an error naming the letter and position", func() {
+			parser := NewTemplateParser(&testWordGenerator{
+				funcName: "noun",
+				function: func(letter string) string {
+					if letter == "7" {
+						return ""
+					}
+
+					return "apple"
+				},
+			})
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+			parameters["Param2"] = "7"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }} {{ .Param2 | noun }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a", "7"}, actual, EscapePlain)
+
+			Expect(err).To(Equal(&NoWordError{Letter: "7", Position: 2}))
+			Expect(err.Error()).To(Equal(`no word found for "7" at position 2`))
+		})
+		It("Gives the letter's position in the input, not the word's in the mnemonic", func() {
+			parser := NewTemplateParser(&testWordGenerator{
+				funcName: "noun",
+				function: func(letter string) string {
+					if letter == "7" {
+						return ""
+					}
+
+					return "apple"
+				},
+			})
+			template := NewTemplateFromSlots([]Slot{
+				{Letter: "a", Index: 1, PartOfSpeech: "noun"},
+				{Letter: "7", Index: 0, PartOfSpeech: "noun", EndsSentence: true},
+			})
+
+			_, err := parser.Generate(context.Background(), template, []string{"7", "a"})
+
+			Expect(err).To(Equal(&NoWordError{Letter: "7", Position: 1}))
+		})
+	})
+	Context("Generator errors", func() {
+		It("Returns the error from the generator", func() {
+			generatorErr := errors.New("dictionary went away")
+			parser := NewTemplateParserV2(&testWordGeneratorV2{
+				funcName: "noun",
+				function: func(ctx context.Context, letter string) (Word, error) {
+					return Word{}, generatorErr
+				},
+			})
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a"}, actual, EscapePlain)
+
+			Expect(err).To(Equal(generatorErr))
+		})
+		It("Stops when the context is cancelled", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("apple", "noun"))
+			actual := &bytes.Buffer{}
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(ctx, template, []string{"a"}, actual, EscapePlain)
+
+			Expect(err).To(Equal(context.Canceled))
+		})
+		It("Uses the words from a WordGeneratorV2", func() {
+			parser := NewTemplateParserV2(&testWordGeneratorV2{
+				funcName: "noun",
+				function: func(ctx context.Context, letter string) (Word, error) {
+					return Word{Lemma: "apple", PartOfSpeech: "noun"}, nil
+				},
+			})
+			actual := &bytes.Buffer{}
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			err := parser.Parse(context.Background(), template, []string{"a"}, actual, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.String()).To(Equal("apple"))
+		})
+	})
+	Context("Generate", func() {
+		It("Returns each word with the letter and slot it came from", func() {
+			parser := NewTemplateParserV2(
+				&testWordGeneratorV2{
+					funcName: "adj",
+					function: func(ctx context.Context, letter string) (Word, error) {
+						return Word{}, ErrNoWord
+					},
+				},
+				&testWordGeneratorV2{
+					funcName: "noun",
+					function: func(ctx context.Context, letter string) (Word, error) {
+						return Word{Lemma: letter + "pple", PartOfSpeech: "noun", Source: "test"}, nil
+					},
+				},
+			)
+
+			actual, err := parser.Generate(context.Background(), NewTemplate([]string{"a", "b"}), []string{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("apple bpple."))
+			Expect(actual.Words).To(Equal([]ChosenWord{
+				{
+					Word:      Word{Lemma: "apple", PartOfSpeech: "noun", Source: "test"},
+					Letter:    "a",
+					Index:     0,
+					Sentence:  0,
+					Generator: "noun",
+				},
+				{
+					Word:      Word{Lemma: "bpple", PartOfSpeech: "noun", Source: "test"},
+					Letter:    "b",
+					Index:     1,
+					Sentence:  0,
+					Generator: "noun",
+				},
+			}))
+		})
+		It("Records placeholders", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("", "noun"))
+			parser.SetPlaceholder("?")
+
+			actual, err := parser.Generate(context.Background(), NewTemplate([]string{"7"}), []string{})
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("?."))
+			Expect(actual.Words).To(Equal([]ChosenWord{
+				{Word: Word{Lemma: "?", Source: "placeholder"}, Letter: "7"},
+			}))
+		})
+	})
+	Context("Alternatives", func() {
+		It("Returns the requested number of different mnemonics", func() {
+			words := []string{"apple", "avocado", "apricot"}
+			calls := 0
+			parser := NewTemplateParser(&testWordGenerator{
+				funcName: "noun",
+				function: func(letter string) string {
+					calls++
+					return words[calls%len(words)]
+				},
+			})
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			actual, err := parser.ParseAlternatives(context.Background(), template, []string{"a"}, 3, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(ConsistOf("apple", "avocado", "apricot"))
+		})
+		It("Removes duplicates and gives up when it runs out of words", func() {
+			parser := NewTemplateParser(NewStaticWordGenerator("apple", "noun"))
+
+			parameters := make(map[string]string)
+			parameters["Param1"] = "a"
+
+			template := testTemplate{
+				template:   "{{ .Param1 | noun }}",
+				parameters: parameters,
+			}
+
+			actual, err := parser.ParseAlternatives(context.Background(), template, []string{"a"}, 3, EscapePlain)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual).To(Equal([]string{"apple"}))
+		})
+		It("Returns template errors", func() {
+			parser := NewTemplateParser()
+
+			template := testTemplate{
+				template: "{{ .Param1 | missing }}",
+			}
+
+			_, err := parser.ParseAlternatives(context.Background(), template, []string{"a"}, 3, EscapePlain)
+
+			Expect(err).To(HaveOccurred())
+		})
+	})
 })
 
 func ExampleNewTemplateParser() {
@@ -154,8 +535,7 @@ func ExampleTemplateParserBase_Parse() {
 	)
 
 	buffer := &bytes.Buffer{}
-	writer := bufio.NewWriter(buffer)
-	err := generator.Parse(template, letters, writer)
+	err := generator.Parse(context.Background(), template, letters, buffer, EscapePlain)
 
 	if err != nil {
 		log.Fatal(err)
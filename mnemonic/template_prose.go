@@ -0,0 +1,333 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DefaultMinWords is the fewest words in a sentence of prose, unless it's set
+const DefaultMinWords = 2
+
+// DefaultMaxWords is the most words in a sentence of prose, unless it's set
+const DefaultMaxWords = 6
+
+// optionalMarker ends the words in a sentence shape that can be left out
+const optionalMarker = "?"
+
+// leftoverWeight is how likely a sentence shorter than the fewest words is, a last resort so Plan only uses one when
+// the letters don't fit otherwise
+const leftoverWeight = lastResortWeight
+
+// sentenceShapes are the kinds of sentence prose is made of, parts of speech ending in ? can be left out
+var sentenceShapes = [][]string{
+	// A noun phrase on its own
+	{"adj?", "adj?", "noun", "."},
+	// Subject, verb, object
+	{"adj?", "noun", "verb", "adj?", "noun", "adv?", "."},
+	// Adjective, noun, verb
+	{"adj", "adj?", "noun", "verb", "adv?", "."},
+	// Noun, verb, adverb
+	{"noun", "verb", "adv", "."},
+	// Questions
+	{`"why do"`, "adj?", "noun", "verb", "adv?", "?"},
+	{`"does the"`, "adj?", "noun", "verb", `"the"`, "adj?", "noun", "?"},
+	// With function words, which need words from the closed-class lexicon
+	{"pron", "verb", "prep", "det", "adj?", "noun", "."},
+	{"det", "adj?", "noun", "verb", "prep", "noun", "."},
+	{"noun", "conj", "noun", "verb", "adv?", "."},
+	// Imperatives
+	{"verb", `"the"`, "adj?", "noun", "adv?", "!"},
+	{"verb", "adv", "!"},
+}
+
+// NewProseGrammar returns a grammar for sentences of between minWords and maxWords words, in varied shapes
+//
+// The shapes are subject, verb, object sentences, adjective, noun, verb, noun, verb, adverb, questions and
+// imperatives, with up to six words. Longer sentences join clauses with a conjunction, or "and", after a comma. Some
+// use the closed-class parts of speech, see ClosedClassLexicon. When the letters can't be split into sentences that
+// long, the last sentence can be shorter.
+//
+// Could be used like
+//   grammar, err := mnemonic.NewProseGrammar(3, 5)
+func NewProseGrammar(minWords int, maxWords int) (*Grammar, error) {
+	if minWords < 1 {
+		minWords = 1
+	}
+
+	if maxWords < minWords {
+		return nil, fmt.Errorf("the most words in a sentence, %d, is fewer than the least, %d", maxWords, minWords)
+	}
+
+	rules := newProseRules()
+	sentences := rules.sentenceAlternatives(minWords, maxWords)
+
+	if len(sentences) == 0 {
+		return nil, fmt.Errorf("there are no sentences of between %d and %d words", minWords, maxWords)
+	}
+
+	lines := []string{
+		"mnemonic = sentence | sentence mnemonic",
+		"sentence = " + strings.Join(sentences, " | "),
+	}
+
+	if leftovers := rules.sentenceAlternatives(1, minWords-1); len(leftovers) > 0 {
+		lines[0] += fmt.Sprintf(" | leftover [%g]", leftoverWeight)
+		lines = append(lines, "leftover = "+strings.Join(leftovers, " | "))
+	}
+
+	lines = append(lines, rules.grammarLines()...)
+
+	return ReadGrammar(strings.NewReader(strings.Join(lines, "\n")))
+}
+
+// NewProseTemplate returns a template laying the letters out as sentences of between minWords and maxWords words
+//
+// The shape of each sentence is picked using random, so the same seed gives the same layout.
+//
+// Might be used like this:
+//   template, err := mnemonic.NewProseTemplate(letters, 2, 6, mnemonic.NewSeededRandomSource(42))
+func NewProseTemplate(letters []string, minWords int, maxWords int, random RandomSource) (*TemplateBase, error) {
+	grammar, err := NewProseGrammar(minWords, maxWords)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return grammar.Expand(letters, random)
+}
+
+// proseRules are the rules for the clauses that longer sentences are joined from, made as they're needed
+type proseRules struct {
+	// clauses are the ways of filling in the clause shapes, keyed by how many words they have
+	clauses map[int][]string
+	// lengths are the numbers of words clauses can have, shortest first
+	lengths []int
+	// joined are the names of the rules for clauses joined together, keyed by how many words they have, with an
+	// empty name when no clauses add up to that many
+	joined map[int]string
+	// lines are the joined rules, made as they're needed
+	lines []string
+}
+
+// newProseRules returns the clauses from the sentence shapes that are statements with a verb in
+func newProseRules() *proseRules {
+	rules := &proseRules{clauses: make(map[int][]string), joined: make(map[int]string)}
+
+	for _, shape := range sentenceShapes {
+		if shape[len(shape)-1] != "." || !containsString(shape, "verb") {
+			continue
+		}
+
+		for _, clause := range fillSentenceShape(shape[:len(shape)-1]) {
+			words := countShapeWords(clause)
+
+			if len(rules.clauses[words]) == 0 {
+				rules.lengths = append(rules.lengths, words)
+			}
+
+			rules.clauses[words] = append(rules.clauses[words], strings.Join(clause, " "))
+		}
+	}
+
+	sort.Ints(rules.lengths)
+
+	return rules
+}
+
+// grammarLines returns the rules for the clauses and joined clauses, if any sentences are joined from them
+func (r *proseRules) grammarLines() []string {
+	if len(r.lines) == 0 {
+		return nil
+	}
+
+	lines := []string{}
+
+	for _, words := range r.lengths {
+		lines = append(
+			lines,
+			fmt.Sprintf("clause%d = %s", words, strings.Join(r.clauses[words], " | ")),
+			fmt.Sprintf("opening%d = %s", words, strings.Join(capitaliseShapes(r.clauses[words]), " | ")),
+		)
+	}
+
+	return append(lines, r.lines...)
+}
+
+// sentenceAlternatives returns every way of filling in the sentence shapes with between minWords and maxWords words
+//
+// Every shape is as likely as any other, however many ways there are to fill it in. Sentences longer than any shape
+// are clauses joined together, with each length as likely as a shape.
+func (r *proseRules) sentenceAlternatives(minWords int, maxWords int) []string {
+	alternatives := []string{}
+
+	for _, shape := range sentenceShapes {
+		filled := []string{}
+
+		for _, sentence := range fillSentenceShape(shape) {
+			if words := countShapeWords(sentence); words >= minWords && words <= maxWords {
+				sentence[0] = "^" + sentence[0]
+				filled = append(filled, strings.Join(sentence, " "))
+			}
+		}
+
+		alternatives = append(alternatives, weighAlternatives(filled)...)
+	}
+
+	for words := longestSentence() + 1; words <= maxWords; words++ {
+		if words < minWords {
+			continue
+		}
+
+		joined := []string{}
+
+		for _, first := range r.lengths {
+			if rest := r.joinedRule(words - first - 1); rest != "" {
+				joined = append(joined, fmt.Sprintf("opening%d , conj %s .", first, rest))
+			}
+
+			if rest := r.joinedRule(words - first); rest != "" {
+				joined = append(joined, fmt.Sprintf(`opening%d , "and" %s .`, first, rest))
+			}
+		}
+
+		alternatives = append(alternatives, weighAlternatives(joined)...)
+	}
+
+	return alternatives
+}
+
+// joinedRule returns the name of the rule for one or more clauses joined together with that many words, adding it
+// if it isn't there yet, or nothing if they can't add up to that many
+func (r *proseRules) joinedRule(words int) string {
+	if name, ok := r.joined[words]; ok || words < 1 {
+		return name
+	}
+
+	// Stops clauses that can't add up to this many being tried again
+	r.joined[words] = ""
+
+	alternatives := []string{}
+
+	if len(r.clauses[words]) > 0 {
+		alternatives = append(alternatives, fmt.Sprintf("clause%d", words))
+	}
+
+	for _, first := range r.lengths {
+		if rest := r.joinedRule(words - first - 1); rest != "" {
+			alternatives = append(alternatives, fmt.Sprintf("clause%d , conj %s", first, rest))
+		}
+
+		if rest := r.joinedRule(words - first); rest != "" {
+			alternatives = append(alternatives, fmt.Sprintf(`clause%d , "and" %s`, first, rest))
+		}
+	}
+
+	if len(alternatives) > 0 {
+		r.joined[words] = fmt.Sprintf("joined%d", words)
+		r.lines = append(r.lines, fmt.Sprintf("%s = %s", r.joined[words], strings.Join(alternatives, " | ")))
+	}
+
+	return r.joined[words]
+}
+
+// weighAlternatives gives each alternative an equal share of a weight of 1
+func weighAlternatives(alternatives []string) []string {
+	weighed := []string{}
+
+	for i := range alternatives {
+		weighed = append(weighed, fmt.Sprintf("%s [%g]", alternatives[i], 1/float64(len(alternatives))))
+	}
+
+	return weighed
+}
+
+// capitaliseShapes returns the filled in shapes with a ^ before each, so their first word starts with a capital
+func capitaliseShapes(shapes []string) []string {
+	capitalised := []string{}
+
+	for _, shape := range shapes {
+		capitalised = append(capitalised, "^"+shape)
+	}
+
+	return capitalised
+}
+
+// containsString returns true if the value is one of the values
+func containsString(values []string, value string) bool {
+	for i := range values {
+		if values[i] == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+// fillSentenceShape returns the shape with every combination of its optional words left in or out
+func fillSentenceShape(shape []string) [][]string {
+	sentences := [][]string{{}}
+
+	for _, part := range shape {
+		next := [][]string{}
+		word := strings.TrimSuffix(part, optionalMarker)
+		optional := word != part && isGrammarName(word)
+
+		if !optional {
+			word = part
+		}
+
+		for _, sentence := range sentences {
+			next = append(next, append(append([]string{}, sentence...), word))
+
+			if optional {
+				next = append(next, sentence)
+			}
+		}
+
+		sentences = next
+	}
+
+	return sentences
+}
+
+// longestSentence returns the most words any of the sentence shapes can have
+func longestSentence() int {
+	longest := 0
+
+	for _, shape := range sentenceShapes {
+		if words := countShapeWords(fillSentenceShape(shape)[0]); words > longest {
+			longest = words
+		}
+	}
+
+	return longest
+}
+
+// countShapeWords returns how many letters a filled in shape stands for
+func countShapeWords(sentence []string) int {
+	words := 0
+
+	for _, part := range sentence {
+		if isGrammarName(part) {
+			words++
+		}
+	}
+
+	return words
+}
@@ -0,0 +1,171 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"strings"
+	"unicode"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+// sentenceLengths returns how many letters each sentence in a template stands for
+func sentenceLengths(template Template) []int {
+	lengths := []int{}
+
+	for _, slot := range template.GetSlots() {
+		if slot.Literal != "" {
+			continue
+		}
+
+		for len(lengths) <= slot.Sentence {
+			lengths = append(lengths, 0)
+		}
+
+		lengths[slot.Sentence]++
+	}
+
+	return lengths
+}
+
+var _ = Describe("Prose", func() {
+	letters := strings.Split("thequickbrownfoxjumpsoverthelazydog", "")
+
+	It("Keeps sentences between the fewest and most words", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := NewProseTemplate(letters, 3, 5, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, length := range sentenceLengths(actual) {
+				Expect(length).To(BeNumerically(">=", 3))
+				Expect(length).To(BeNumerically("<=", 5))
+			}
+		}
+	})
+	It("Varies the shape of the sentences, the same way for the same seed", func() {
+		layouts := make(map[string]bool)
+		text := ""
+
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := NewProseTemplate(letters, 2, 6, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			again, err := NewProseTemplate(letters, 2, 6, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again.GetTemplate()).To(Equal(actual.GetTemplate()))
+
+			layouts[actual.GetTemplate()] = true
+			text += actual.GetTemplate()
+		}
+
+		Expect(layouts).To(HaveLen(20))
+		Expect(text).To(ContainSubstring("?"))
+		Expect(text).To(ContainSubstring("!"))
+		Expect(text).To(ContainSubstring(`{{ "the" }}`))
+	})
+	It("Makes the last sentence shorter only when the letters don't fit otherwise", func() {
+		actual, err := NewProseTemplate(letters[:7], 3, 3, NewSeededRandomSource(42))
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(sentenceLengths(actual)).To(ContainElement(1))
+
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := NewProseTemplate(letters[:12], 2, 6, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(sentenceLengths(actual)).NotTo(ContainElement(1))
+		}
+	})
+	It("Never generates a sentence shorter than the fewest words when the letters can be split up without one", func() {
+		for _, input := range []struct {
+			letters  string
+			minWords int
+		}{{"ROYGBIV", 2}, {"ROYGBIV", 3}, {"THEQUICKBROWN", 4}} {
+			for seed := int64(0); seed < 200; seed++ {
+				actual, err := Generate(
+					context.Background(),
+					input.letters,
+					WithSentenceLength(input.minWords, DefaultMaxWords),
+					WithSeed(seed),
+				)
+				Expect(err).NotTo(HaveOccurred())
+
+				lengths := make(map[int]int)
+
+				for _, word := range actual.Words {
+					lengths[word.Sentence]++
+				}
+
+				for _, length := range lengths {
+					Expect(length).To(BeNumerically(">=", input.minWords), actual.Text())
+				}
+			}
+		}
+	})
+	It("Rejects sentence lengths it can't make", func() {
+		_, err := NewProseGrammar(4, 3)
+		Expect(err).To(MatchError("the most words in a sentence, 3, is fewer than the least, 4"))
+	})
+	It("Joins clauses for sentences longer than any shape", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			actual, err := NewProseTemplate(letters, 7, 12, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			lengths := sentenceLengths(actual)
+
+			for _, length := range lengths[:len(lengths)-1] {
+				Expect(length).To(BeNumerically(">=", 7))
+				Expect(length).To(BeNumerically("<=", 12))
+			}
+
+			Expect(actual.GetTemplate()).To(MatchRegexp(`, ({{ "and" }}|{{ .Param\d+ \| conj }}) `))
+		}
+	})
+	It("Starts sentences with a capital letter", func() {
+		for seed := int64(0); seed < 20; seed++ {
+			for _, maxWords := range []int{6, 12} {
+				actual, err := NewProseTemplate(letters, 2, maxWords, NewSeededRandomSource(seed))
+				Expect(err).NotTo(HaveOccurred())
+
+				slots := actual.GetSlots()
+
+				for i, slot := range slots {
+					starts := i == 0 || slots[i-1].Sentence != slot.Sentence
+					capitalised := slot.Title || slot.Literal != "" && unicode.IsUpper([]rune(slot.Literal)[0])
+
+					Expect(capitalised).To(Equal(starts), actual.GetTemplate())
+				}
+			}
+		}
+	})
+	It("Generates prose", func() {
+		actual, err := Generate(
+			context.Background(),
+			"abcdef",
+			WithWordGenerators(testGenerators()...),
+			WithTemplateStyle(StyleProse),
+			WithSentenceLength(2, 2),
+			WithSeed(42),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Words).To(HaveLen(6))
+		Expect(actual.Words[5].Sentence).To(Equal(2))
+	})
+})
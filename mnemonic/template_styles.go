@@ -0,0 +1,165 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// TemplateStyle is the name of a way of laying out a mnemonic
+type TemplateStyle string
+
+const (
+	// StyleSentence is sentences of up to four words, an adjective, a noun, a verb and an adverb
+	StyleSentence TemplateStyle = "sentence"
+	// StyleHeadline is title cased noun phrases, like a newspaper headline
+	StyleHeadline TemplateStyle = "headline"
+	// StyleStory is sentences of prose, with the words that join them up
+	StyleStory TemplateStyle = "story"
+	// StyleList is one noun per line
+	StyleList TemplateStyle = "list"
+	// StylePoem is short lines of verse
+	StylePoem TemplateStyle = "poem"
+	// StyleProse is sentences of varied shapes and lengths, see NewProseGrammar
+	StyleProse TemplateStyle = "prose"
+)
+
+// headlineGrammar is noun phrases joined up like a headline
+const headlineGrammar = `
+headline = title | title : title | title , headline
+title    = ^phrase | ^phrase "and" ^phrase | ^phrase "of the" ^phrase
+phrase   = noun | adj noun | adj adj noun [0.5]
+`
+
+// storyGrammar is sentences that read like a story
+const storyGrammar = `
+story    = sentence | sentence story
+sentence = ^"the" adj noun verb adv . [2]
+         | ^"the" noun verb "the" adj noun . [2]
+         | ^"the" noun verb adv .
+         | ^"then the" noun verb .
+         | ^adj noun verb "the" noun .
+         | ^"then came the" noun . [0.5]
+`
+
+// listGrammar is a noun on each line
+const listGrammar = `
+list = noun | noun / list
+`
+
+// poemGrammar is lines of verse, each starting with a capital
+const poemGrammar = `
+poem = line . | line , / poem [3]
+line = ^noun | ^noun verb | ^adj noun | ^noun verb adv | ^adj noun verb | ^adj noun verb adv
+`
+
+// templateStyles are the grammars for the template styles Generate knows how to make
+var templateStyles = map[TemplateStyle]string{
+	StyleSentence: defaultGrammar,
+	StyleHeadline: headlineGrammar,
+	StyleStory:    storyGrammar,
+	StyleList:     listGrammar,
+	StylePoem:     poemGrammar,
+	// Prose is built by NewProseGrammar for the sentence lengths asked for
+	StyleProse: "",
+}
+
+// TemplateStyles returns the names of the template styles, in alphabetical order
+func TemplateStyles() []TemplateStyle {
+	styles := []TemplateStyle{}
+
+	for style := range templateStyles {
+		styles = append(styles, style)
+	}
+
+	sort.Slice(styles, func(i, j int) bool { return styles[i] < styles[j] })
+
+	return styles
+}
+
+// StyleGrammar returns the grammar for a template style
+//
+// Prose has sentences of between DefaultMinWords and DefaultMaxWords words, use NewProseGrammar for other lengths.
+//
+// Could be used like
+//   grammar, err := mnemonic.StyleGrammar(mnemonic.StylePoem)
+func StyleGrammar(style TemplateStyle) (*Grammar, error) {
+	text, ok := templateStyles[style]
+
+	if !ok {
+		return nil, fmt.Errorf("unknown template style %q", style)
+	}
+
+	if style == StyleProse {
+		return NewProseGrammar(DefaultMinWords, DefaultMaxWords)
+	}
+
+	return ReadGrammar(strings.NewReader(text))
+}
+
+// NewHeadlineTemplate returns a template laying the letters out as a headline of title cased noun phrases
+//
+// Might be used like this:
+//   template := mnemonic.NewHeadlineTemplate([]string{"e", "x", "a", "m", "p", "l", "e"})
+func NewHeadlineTemplate(letters []string) *TemplateBase {
+	return newStyleTemplate(StyleHeadline, letters)
+}
+
+// NewStoryTemplate returns a template laying the letters out as sentences of a story
+//
+// Might be used like this:
+//   template := mnemonic.NewStoryTemplate([]string{"e", "x", "a", "m", "p", "l", "e"})
+func NewStoryTemplate(letters []string) *TemplateBase {
+	return newStyleTemplate(StyleStory, letters)
+}
+
+// NewListTemplate returns a template laying the letters out as a noun on each line
+//
+// Might be used like this:
+//   template := mnemonic.NewListTemplate([]string{"e", "x", "a", "m", "p", "l", "e"})
+func NewListTemplate(letters []string) *TemplateBase {
+	return newStyleTemplate(StyleList, letters)
+}
+
+// NewPoemTemplate returns a template laying the letters out as lines of a poem
+//
+// Might be used like this:
+//   template := mnemonic.NewPoemTemplate([]string{"e", "x", "a", "m", "p", "l", "e"})
+func NewPoemTemplate(letters []string) *TemplateBase {
+	return newStyleTemplate(StylePoem, letters)
+}
+
+// newStyleTemplate lays the letters out in a style, always the same way for the same number of letters
+//
+// Every style has a layout for any number of letters, so it can't fail. Generate picks between layouts using its
+// seed instead.
+func newStyleTemplate(style TemplateStyle, letters []string) *TemplateBase {
+	grammar, err := StyleGrammar(style)
+
+	if err != nil {
+		panic(err)
+	}
+
+	template, err := grammar.Expand(letters, NewSeededRandomSource(0))
+
+	if err != nil {
+		panic(err)
+	}
+
+	return template
+}
@@ -0,0 +1,136 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Template styles", func() {
+	letters := strings.Split("abcdefghij", "")
+
+	It("Puts a word on each line of a list", func() {
+		actual := NewListTemplate([]string{"a", "b", "c"})
+
+		Expect(actual.GetTemplate()).To(Equal("{{ .Param1 | noun }}\n{{ .Param2 | noun }}\n{{ .Param3 | noun }}"))
+	})
+	It("Title cases headlines", func() {
+		actual := NewHeadlineTemplate(letters)
+
+		for _, slot := range actual.GetSlots() {
+			if slot.Literal == "" {
+				Expect(slot.Title).To(BeTrue())
+			}
+		}
+
+		Expect(actual.GetTemplate()).To(ContainSubstring("| title }}"))
+	})
+	It("Breaks poems into lines", func() {
+		actual := NewPoemTemplate(letters)
+
+		Expect(actual.GetTemplate()).To(ContainSubstring(",\n"))
+		Expect(actual.GetTemplate()).To(HaveSuffix("."))
+	})
+	It("Writes stories in sentences", func() {
+		actual := NewStoryTemplate(letters)
+		slots := actual.GetSlots()
+
+		Expect(slots[0].Literal).To(HavePrefix("The"))
+		Expect(slots[len(slots)-1].EndsSentence).To(BeTrue())
+	})
+	It("Only capitalises the first word of the text that starts a story's sentences", func() {
+		grammar, err := StyleGrammar(StyleStory)
+		Expect(err).NotTo(HaveOccurred())
+		literals := make(map[string]bool)
+
+		for seed := int64(0); seed < 50; seed++ {
+			template, err := grammar.Expand(letters, NewSeededRandomSource(seed))
+			Expect(err).NotTo(HaveOccurred())
+
+			for _, slot := range template.GetSlots() {
+				literals[slot.Literal] = true
+			}
+		}
+
+		Expect(literals).To(HaveKey("Then came the"))
+		Expect(literals).To(HaveKey("Then the"))
+		Expect(literals).To(HaveKey("The"))
+		Expect(literals).To(HaveKey("the"))
+		Expect(literals).NotTo(HaveKey("Then Came The"))
+		Expect(literals).NotTo(HaveKey("Then The"))
+	})
+	It("Lays out any number of letters in every style", func() {
+		for _, style := range TemplateStyles() {
+			grammar, err := StyleGrammar(style)
+			Expect(err).NotTo(HaveOccurred())
+
+			for length := 1; length < 30; length++ {
+				_, err := grammar.Expand(strings.Split(strings.Repeat("x", length), ""), NewSeededRandomSource(42))
+
+				Expect(err).NotTo(HaveOccurred(), string(style))
+			}
+		}
+	})
+	It("Lists the styles", func() {
+		Expect(TemplateStyles()).To(Equal([]TemplateStyle{
+			StyleHeadline,
+			StyleList,
+			StylePoem,
+			StyleProse,
+			StyleSentence,
+			StyleStory,
+		}))
+	})
+	It("Rejects unknown styles", func() {
+		_, err := StyleGrammar("limerick")
+
+		Expect(err).To(MatchError(`unknown template style "limerick"`))
+	})
+	It("Generates in a style", func() {
+		actual, err := Generate(
+			context.Background(),
+			"a",
+			WithWordGenerators(testGenerators()...),
+			WithTemplateStyle(StyleHeadline),
+		)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Text()).To(Equal("A-noun"))
+	})
+})
+
+func ExampleNewListTemplate() {
+	result, err := NewTemplateParserV2(
+		NewWordGeneratorAdapter(NewStaticWordGenerator("eggs", "noun")),
+	).Generate(context.Background(), NewListTemplate([]string{"e", "e"}), nil)
+
+	if err != nil {
+		log.Fatal(err)
+		return
+	}
+
+	fmt.Println(result)
+	// Output:
+	// eggs
+	// eggs
+}
@@ -158,6 +158,50 @@ var _ = Describe("TemplateParser", func() {
 			Expect(actual.GetParameters()).To(Equal(parameters))
 		})
 	})
+	Context("Get slots", func() {
+		It("No slots", func() {
+			actual := NewTemplate([]string{})
+
+			Expect(actual.GetSlots()).To(Equal([]Slot{}))
+		})
+		It("A single noun sentence", func() {
+			actual := NewTemplate([]string{"a"})
+
+			Expect(actual.GetSlots()).To(Equal([]Slot{
+				{Letter: "a", Index: 0, PartOfSpeech: "noun", Sentence: 0, EndsSentence: true},
+			}))
+		})
+		It("Splits into sentences of 4", func() {
+			actual := NewTemplate([]string{"a", "b", "c", "d", "e", "f"})
+
+			Expect(actual.GetSlots()).To(Equal([]Slot{
+				{Letter: "a", Index: 0, PartOfSpeech: "adj", Sentence: 0},
+				{Letter: "b", Index: 1, PartOfSpeech: "noun", Sentence: 0},
+				{Letter: "c", Index: 2, PartOfSpeech: "verb", Sentence: 0},
+				{Letter: "d", Index: 3, PartOfSpeech: "adv", Sentence: 0, EndsSentence: true},
+				{Letter: "e", Index: 4, PartOfSpeech: "adj", Sentence: 1},
+				{Letter: "f", Index: 5, PartOfSpeech: "noun", Sentence: 1, EndsSentence: true},
+			}))
+		})
+		It("Changing the returned slots doesn't change the template", func() {
+			actual := NewTemplate([]string{"a"})
+			actual.GetSlots()[0].PartOfSpeech = "verb"
+
+			Expect(actual.GetTemplate()).To(Equal("{{ .Param1 | noun }}."))
+		})
+	})
+	Context("From slots", func() {
+		It("Renders the slots it is given", func() {
+			actual := NewTemplateFromSlots([]Slot{
+				{Letter: "x", Index: 0, PartOfSpeech: "verb"},
+				{Letter: "y", Index: 1, PartOfSpeech: "noun", EndsSentence: true},
+			})
+
+			Expect(actual.GetTemplate()).To(Equal("{{ .Param1 | verb }} {{ .Param2 | noun }}."))
+			Expect(actual.GetUsedFunctions()).To(Equal([]string{"verb", "noun"}))
+			Expect(actual.GetParameters()).To(Equal(map[string]string{"Param1": "x", "Param2": "y"}))
+		})
+	})
 })
 
 func ExampleNewTemplate() {
@@ -184,3 +228,20 @@ func ExampleTemplateBase_GetParameters() {
 	}
 	// Output: Key: Param1 Value: a
 }
+
+func ExampleNewTemplateFromSlots() {
+	slots := NewTemplate([]string{"a", "b"}).GetSlots()
+	slots[0].PartOfSpeech = "verb"
+
+	fmt.Println(NewTemplateFromSlots(slots).GetTemplate())
+	// Output: {{ .Param1 | verb }} {{ .Param2 | noun }}.
+}
+
+func ExampleTemplateBase_GetSlots() {
+	for _, slot := range NewTemplate([]string{"a", "b"}).GetSlots() {
+		fmt.Println(slot.Letter, slot.PartOfSpeech)
+	}
+	// Output:
+	// a adj
+	// b noun
+}
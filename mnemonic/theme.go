@@ -0,0 +1,214 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// themeRelatedScore is how close to the theme a word one relation away from it is
+	themeRelatedScore = 0.6
+	// themeDistantScore is how close to the theme a word two relations away from it is
+	themeDistantScore = 0.3
+	// themeGlossScore is how close to the theme a word is when its gloss mentions the theme
+	themeGlossScore = 0.25
+)
+
+// themeRelations are the WordNet pointers followed to find words near a theme
+var themeRelations = map[string]bool{
+	"@":  true, // hypernym
+	"@i": true, // instance hypernym
+	"~":  true, // hyponym
+	"~i": true, // instance hyponym
+	"#m": true, // member holonym
+	"#s": true, // substance holonym
+	"#p": true, // part holonym
+	"%m": true, // member meronym
+	"%s": true, // substance meronym
+	"%p": true, // part meronym
+	";c": true, // topic domain
+	"-c": true, // member of topic domain
+	"^":  true, // also see
+	"&":  true, // similar to
+}
+
+// Theme is a topic, and how close words are to it from 0 to 1
+type Theme struct {
+	Topic  string
+	scores map[string]float64
+	// synonyms are the words as close as the topic, looked for in glosses, worked out when first needed
+	synonyms []string
+}
+
+// NewTheme returns a theme with only the topic in it
+//
+// Words are also near the theme when they're tagged with the topic, or their gloss mentions it.
+//
+// Could be used like
+//   theme := mnemonic.NewTheme("ocean")
+func NewTheme(topic string) *Theme {
+	theme := &Theme{Topic: topic, scores: make(map[string]float64)}
+	theme.Add(topic, 1)
+
+	return theme
+}
+
+// Add puts a word near the theme, if it isn't already closer
+func (t *Theme) Add(word string, score float64) {
+	key := contentKey(word)
+
+	if score > t.scores[key] {
+		t.scores[key] = score
+		t.synonyms = nil
+	}
+}
+
+// AddRelations puts the words the lexicon relates to the topic near the theme
+//
+// Synonyms of the topic are as close as the topic, then words one relation away, like its hypernyms, hyponyms,
+// parts and topic domain, and then words two relations away, like the other hyponyms of its hypernym. The relations
+// are the ones on the entries, which WordNet dictionaries have and the index cache keeps.
+//
+// Could be used like
+//   theme.AddRelations(lexicon)
+func (t *Theme) AddRelations(lexicon *Lexicon) {
+	words := make(map[string][]string)
+	related := make(map[string][]string)
+	frontier := []string{}
+	topic := contentKey(t.Topic)
+
+	for _, entry := range lexicon.Entries() {
+		if entry.SynsetID == "" {
+			continue
+		}
+
+		words[entry.SynsetID] = append(words[entry.SynsetID], entry.Lemma)
+		related[entry.SynsetID] = entry.Related
+
+		if contentKey(entry.Lemma) == topic {
+			frontier = append(frontier, entry.SynsetID)
+		}
+	}
+
+	visited := make(map[string]bool)
+
+	for _, score := range []float64{1, themeRelatedScore, themeDistantScore} {
+		next := []string{}
+
+		for _, id := range frontier {
+			if visited[id] {
+				continue
+			}
+
+			visited[id] = true
+
+			for _, word := range words[id] {
+				t.Add(word, score)
+			}
+
+			next = append(next, related[id]...)
+		}
+
+		frontier = next
+	}
+}
+
+// Score returns how close an entry is to the theme, from 0 for unrelated to 1 for the topic itself
+func (t *Theme) Score(entry LexiconEntry) float64 {
+	score := t.scores[contentKey(entry.Lemma)]
+
+	for _, tag := range entry.Tags {
+		if contentKey(tag) == contentKey(t.Topic) {
+			return 1
+		}
+	}
+
+	if score < themeGlossScore && t.glossMentions(entry.Gloss) {
+		return themeGlossScore
+	}
+
+	return score
+}
+
+// glossMentions returns true if the gloss has the topic, or one of its synonyms, as a word or phrase in it
+func (t *Theme) glossMentions(gloss string) bool {
+	if gloss == "" {
+		return false
+	}
+
+	if t.synonyms == nil {
+		for word, score := range t.scores {
+			if score == 1 {
+				t.synonyms = append(t.synonyms, word)
+			}
+		}
+	}
+
+	padded := " " + strings.Join(strings.FieldsFunc(strings.ToLower(gloss), isNotLetter), " ") + " "
+
+	for _, word := range t.synonyms {
+		if strings.Contains(padded, " "+word+" ") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Apply returns a lexicon of only the entries near the theme, weighted by how close they are
+//
+// Could be used like
+//   themed := theme.Apply(lexicon)
+func (t *Theme) Apply(lexicon *Lexicon) *Lexicon {
+	entries := []LexiconEntry{}
+
+	for _, entry := range lexicon.Entries() {
+		if score := t.Score(entry); score > 0 {
+			entry.Weight = entry.effectiveWeight() * score
+			entries = append(entries, entry)
+		}
+	}
+
+	return NewLexicon(entries...)
+}
+
+// NewThemedWordGenerators returns the generators, each trying words from the themed lexicon first
+//
+// Words that aren't near the theme are only used when there are none for the letter that are.
+//
+// Could be used like
+//   generators = mnemonic.NewThemedWordGenerators(theme.Apply(lexicon), generators, random)
+func NewThemedWordGenerators(themed *Lexicon, generators []WordGeneratorV2, random RandomSource) []WordGeneratorV2 {
+	wrapped := []WordGeneratorV2{}
+
+	for _, generator := range generators {
+		wrapped = append(wrapped, NewOverlayWordGenerator(
+			NewLexiconWordGenerator(themed, generator.GetFuncName(), random),
+			generator,
+			1,
+			random,
+		))
+	}
+
+	return wrapped
+}
+
+// isNotLetter returns true for anything that separates the words in a gloss
+func isNotLetter(r rune) bool {
+	return !unicode.IsLetter(r) && r != '-' && r != '\''
+}
@@ -0,0 +1,157 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("Theme", func() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "ocean", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "seaweed", PartOfSpeech: "noun", Gloss: "plant growing in the ocean"},
+		LexiconEntry{Lemma: "sand", PartOfSpeech: "noun", Tags: []string{"Ocean"}},
+		LexiconEntry{Lemma: "spanner", PartOfSpeech: "noun", Gloss: "a hand tool"},
+		LexiconEntry{Lemma: "swim", PartOfSpeech: "verb"},
+	)
+
+	It("Scores the topic, tagged words and glosses that mention it", func() {
+		theme := NewTheme("ocean")
+
+		Expect(theme.Score(lexicon.Pool("noun", "o")[0])).To(Equal(1.0))
+		Expect(theme.Score(lexicon.Pool("noun", "s")[0])).To(Equal(0.25))
+		Expect(theme.Score(lexicon.Pool("noun", "s")[1])).To(Equal(1.0))
+		Expect(theme.Score(lexicon.Pool("noun", "s")[2])).To(Equal(0.0))
+	})
+	It("Keeps the closest score for a word", func() {
+		theme := NewTheme("ocean")
+		theme.Add("swim", 0.6)
+		theme.Add("swim", 0.3)
+
+		Expect(theme.Score(LexiconEntry{Lemma: "Swim"})).To(Equal(0.6))
+	})
+	It("Only has the words near the theme in its lexicon", func() {
+		theme := NewTheme("ocean")
+		theme.Add("swim", 0.6)
+
+		themed := theme.Apply(lexicon)
+
+		Expect(themed.Len()).To(Equal(4))
+		Expect(themed.Pool("noun", "s")).To(HaveLen(2))
+		Expect(themed.Pool("verb", "s")[0].Weight).To(Equal(0.6))
+	})
+	It("Finds the words related to the topic in the lexicon", func() {
+		related := NewLexicon(
+			LexiconEntry{Lemma: "ocean", PartOfSpeech: "noun", SynsetID: "1", Related: []string{"2"}},
+			LexiconEntry{Lemma: "main", PartOfSpeech: "noun", SynsetID: "1", Related: []string{"2"}},
+			LexiconEntry{Lemma: "body of water", PartOfSpeech: "noun", SynsetID: "2", Related: []string{"1", "3", "4"}},
+			LexiconEntry{Lemma: "water", PartOfSpeech: "noun", SynsetID: "2", Related: []string{"1", "3", "4"}},
+			LexiconEntry{Lemma: "lake", PartOfSpeech: "noun", SynsetID: "3", Related: []string{"2"}},
+			LexiconEntry{Lemma: "thing", PartOfSpeech: "noun", SynsetID: "4", Related: []string{"2"}},
+			LexiconEntry{Lemma: "spanner", PartOfSpeech: "noun", SynsetID: "5"},
+		)
+		theme := NewTheme("ocean")
+
+		theme.AddRelations(related)
+
+		Expect(theme.Score(LexiconEntry{Lemma: "main"})).To(Equal(1.0))
+		Expect(theme.Score(LexiconEntry{Lemma: "body of water"})).To(Equal(0.6))
+		Expect(theme.Score(LexiconEntry{Lemma: "lake"})).To(Equal(0.3))
+		Expect(theme.Score(LexiconEntry{Lemma: "thing"})).To(Equal(0.3))
+		Expect(theme.Score(LexiconEntry{Lemma: "spanner"})).To(Equal(0.0))
+	})
+	It("Follows relations through words that are left out", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "ocean", PartOfSpeech: "noun", SynsetID: "1", Domain: "noun.object", Related: []string{"2"}},
+			LexiconEntry{Lemma: "walrus", PartOfSpeech: "noun", SynsetID: "2", Domain: "noun.animal"},
+			LexiconEntry{Lemma: "wolf", PartOfSpeech: "noun", SynsetID: "3", Domain: "noun.animal"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"w",
+				WithLexicon(lexicon),
+				WithTheme("ocean"),
+				WithDomains("noun.animal"),
+				WithTemplateStyle(StyleList),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Words[0].Lemma).To(Equal("walrus"))
+		}
+	})
+	It("Uses unrelated words only when there's nothing near the theme", func() {
+		lexicon := NewLexicon(
+			LexiconEntry{Lemma: "salty", PartOfSpeech: "adj", Gloss: "tasting of the ocean"},
+			LexiconEntry{Lemma: "sorry", PartOfSpeech: "adj"},
+			LexiconEntry{Lemma: "wave", PartOfSpeech: "noun", Tags: []string{"ocean"}},
+			LexiconEntry{Lemma: "walrus", PartOfSpeech: "noun"},
+			LexiconEntry{Lemma: "tent", PartOfSpeech: "noun"},
+		)
+
+		for seed := int64(0); seed < 10; seed++ {
+			actual, err := Generate(
+				context.Background(),
+				"swt",
+				WithLexicon(lexicon),
+				WithTheme("ocean"),
+				WithTemplateStyle(StyleSentence),
+				WithSeed(seed),
+			)
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Text()).To(Equal("salty wave tent."))
+		}
+	})
+})
+
+var _ = Describe("LoadWordNetRelations", func() {
+	It("Links each synset to the ones themes follow", func() {
+		dir, err := ioutil.TempDir("", "mnemonic-dict")
+		Expect(err).NotTo(HaveOccurred())
+		defer os.RemoveAll(dir)
+
+		files := map[string]string{
+			"data.noun": "00000001 15 n 02 ocean 0 main 0 002 @ 00000002 n 0000 ;u 00000003 n 0000 | a large body of water\n" +
+				"00000002 15 n 02 body_of_water 0 water 0 002 ~ 00000001 n 0000 + 00000001 v 0101 | water\n" +
+				"00000003 10 n 01 slang 0 000 | informal language\n",
+			"data.verb": "",
+			"data.adj":  "",
+			"data.adv":  "",
+		}
+
+		for name, contents := range files {
+			Expect(ioutil.WriteFile(filepath.Join(dir, name), []byte(contents), 0644)).To(Succeed())
+		}
+
+		actual, err := LoadWordNetRelations(dir)
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(map[string][]string{
+			"00000001-noun": {"00000002-noun"},
+			"00000002-noun": {"00000001-noun"},
+		}))
+	})
+})
@@ -15,12 +15,95 @@
 
 package mnemonic
 
+import (
+	"context"
+	"errors"
+	"math/rand"
+)
+
+// ErrNoWord is returned by a WordGeneratorV2 when it has no word beginning with the letter
+var ErrNoWord = errors.New("no word beginning with that letter")
+
 // WordGenerator Generates random words beginning with a single letter
+//
+// Generate returns an empty string when it has no word beginning with that letter
 type WordGenerator interface {
 	GetFuncName() string
 	Generate(letter string) string
 }
 
+// WordGeneratorV2 generates random words beginning with a single letter, telling you about the word it picked
+//
+// It's the successor to WordGenerator, use NewWordGeneratorAdapter to turn one of those into one of these
+type WordGeneratorV2 interface {
+	GetFuncName() string
+	GenerateWord(ctx context.Context, letter string) (Word, error)
+}
+
+// Word is a word picked by a generator, and what we know about it
+//
+// Anything the generator doesn't know is left empty
+type Word struct {
+	Lemma        string
+	PartOfSpeech string
+	SynsetID     string
+	Gloss        string
+	Source       string
+	Tags         []string
+}
+
+// String returns the word as it should appear in the mnemonic
+func (w Word) String() string {
+	return w.Lemma
+}
+
+// WordGeneratorAdapter lets a WordGenerator be used as a WordGeneratorV2
+type WordGeneratorAdapter struct {
+	generator WordGenerator
+}
+
+// NewWordGeneratorAdapter returns a WordGeneratorV2 that gets its words from a WordGenerator
+//
+// Could be used like
+//   mnemonic.NewWordGeneratorAdapter(mnemonic.NewStaticWordGenerator("soft", "adj"))
+func NewWordGeneratorAdapter(generator WordGenerator) *WordGeneratorAdapter {
+	return &WordGeneratorAdapter{generator: generator}
+}
+
+// GetFuncName gets the function name of the adapted generator
+func (a *WordGeneratorAdapter) GetFuncName() string {
+	return a.generator.GetFuncName()
+}
+
+// GenerateWord returns a word from the adapted generator, with the part of speech set from the function name
+func (a *WordGeneratorAdapter) GenerateWord(ctx context.Context, letter string) (Word, error) {
+	if err := ctx.Err(); err != nil {
+		return Word{}, err
+	}
+
+	word := a.generator.Generate(letter)
+
+	if word == "" {
+		return Word{}, ErrNoWord
+	}
+
+	return Word{Lemma: word, PartOfSpeech: a.generator.GetFuncName()}, nil
+}
+
+// RandomSource is where word generators get their randomness from, *rand.Rand satisfies it
+type RandomSource interface {
+	Intn(n int) int
+}
+
+// NewSeededRandomSource returns a random source that always gives the same sequence for the same seed
+//
+// Could be used like
+//   random := mnemonic.NewSeededRandomSource(42)
+//   mnemonic.NewWnramWordGenerator(wn, wnram.Adjective, random)
+func NewSeededRandomSource(seed int64) RandomSource {
+	return rand.New(rand.NewSource(seed))
+}
+
 func getCharAt(in string, index int) string {
 	return string([]rune(in)[index])
 }
@@ -0,0 +1,95 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import "context"
+
+// filterAttempts is how many words we'll ask for before deciding the filters won't let any through
+const filterAttempts = 100
+
+// WordFilter returns true for words that can be used in a mnemonic
+type WordFilter func(word Word) bool
+
+// poolFilterer is a word generator that can leave the words a filter doesn't keep out of what it picks from
+type poolFilterer interface {
+	filtered(keep WordFilter) WordGeneratorV2
+}
+
+// FilteredWordGenerator is a word generator that only returns words its filters keep
+type FilteredWordGenerator struct {
+	generator WordGeneratorV2
+	filters   []WordFilter
+	// prefiltered is true when the generator only has words the filters keep, so there's no need to check
+	prefiltered bool
+}
+
+// NewFilteredWordGenerator returns a word generator that only returns words all the filters keep
+//
+// Generators that pick from a lexicon have their words filtered once, up front, so a word is found however few get
+// through. Words are asked for from any other generator until one gets through, if none do after a while it gives
+// up with ErrNoWord
+//
+// Could be used like
+//   mnemonic.NewFilteredWordGenerator(generator, func(word mnemonic.Word) bool {
+//     return len(word.Lemma) < 8
+//   })
+func NewFilteredWordGenerator(generator WordGeneratorV2, filters ...WordFilter) *FilteredWordGenerator {
+	filtered := &FilteredWordGenerator{generator: generator, filters: filters}
+
+	if pools, ok := generator.(poolFilterer); ok {
+		filtered.generator = pools.filtered(filtered.keep)
+		filtered.prefiltered = true
+	}
+
+	return filtered
+}
+
+// GetFuncName gets the function name of the filtered generator
+func (f *FilteredWordGenerator) GetFuncName() string {
+	return f.generator.GetFuncName()
+}
+
+// GenerateWord returns a word beginning with the letter that all the filters keep
+func (f *FilteredWordGenerator) GenerateWord(ctx context.Context, letter string) (Word, error) {
+	if f.prefiltered {
+		return f.generator.GenerateWord(ctx, letter)
+	}
+
+	for attempt := 0; attempt < filterAttempts; attempt++ {
+		word, err := f.generator.GenerateWord(ctx, letter)
+
+		if err != nil {
+			return Word{}, err
+		}
+
+		if f.keep(word) {
+			return word, nil
+		}
+	}
+
+	return Word{}, ErrNoWord
+}
+
+// keep returns true if all the filters keep the word
+func (f *FilteredWordGenerator) keep(word Word) bool {
+	for _, filter := range f.filters {
+		if !filter(word) {
+			return false
+		}
+	}
+
+	return true
+}
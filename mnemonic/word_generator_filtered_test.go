@@ -0,0 +1,86 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("FilteredWordGenerator", func() {
+	It("Skips words the filter doesn't keep", func() {
+		words := []string{"awful", "awesome"}
+		calls := 0
+		generator := NewFilteredWordGenerator(
+			NewWordGeneratorAdapter(&testWordGenerator{
+				funcName: "adj",
+				function: func(letter string) string {
+					calls++
+					return words[(calls-1)%len(words)]
+				},
+			}),
+			func(word Word) bool { return word.Lemma != "awful" },
+		)
+
+		actual, err := generator.GenerateWord(context.Background(), "a")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Lemma).To(Equal("awesome"))
+		Expect(generator.GetFuncName()).To(Equal("adj"))
+	})
+	It("Gives up when nothing gets through", func() {
+		generator := NewFilteredWordGenerator(
+			NewWordGeneratorAdapter(NewStaticWordGenerator("awful", "adj")),
+			func(word Word) bool { return false },
+		)
+
+		_, err := generator.GenerateWord(context.Background(), "a")
+
+		Expect(err).To(Equal(ErrNoWord))
+	})
+	It("Finds the only word a filter keeps in a big lexicon", func() {
+		lexicon := NewLexicon(LexiconEntry{Lemma: "awesome", PartOfSpeech: "adj"})
+
+		for i := 0; i < 1000; i++ {
+			lexicon.Add(LexiconEntry{Lemma: fmt.Sprintf("awful%d", i), PartOfSpeech: "adj"})
+		}
+
+		keep := func(word Word) bool { return word.Lemma == "awesome" }
+		random := NewSeededRandomSource(42)
+		generators := []WordGeneratorV2{
+			NewFilteredWordGenerator(NewLexiconWordGenerator(lexicon, "adj", random), keep),
+			NewFilteredWordGenerator(NewOverlayWordGenerator(
+				NewLexiconWordGenerator(NewLexicon(), "adj", random),
+				NewLexiconWordGenerator(lexicon, "adj", random),
+				0.5,
+				random,
+			), keep),
+		}
+
+		for _, generator := range generators {
+			for i := 0; i < 20; i++ {
+				actual, err := generator.GenerateWord(context.Background(), "a")
+
+				Expect(err).NotTo(HaveOccurred())
+				Expect(actual.Lemma).To(Equal("awesome"))
+			}
+		}
+	})
+})
@@ -0,0 +1,93 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import "context"
+
+// LexiconWordGenerator is a word generator that pulls random words of one part of speech from a lexicon
+type LexiconWordGenerator struct {
+	lexicon      *Lexicon
+	partOfSpeech string
+	random       RandomSource
+}
+
+// NewLexiconWordGenerator returns a word generator that pulls random words of one part of speech from a lexicon
+//
+// Could be used like
+//   mnemonic.NewLexiconWordGenerator(lexicon, "adj", mnemonic.NewSeededRandomSource(42))
+func NewLexiconWordGenerator(lexicon *Lexicon, partOfSpeech string, random RandomSource) *LexiconWordGenerator {
+	return &LexiconWordGenerator{lexicon: lexicon, partOfSpeech: partOfSpeech, random: random}
+}
+
+// NewLexiconWordGenerators returns a word generator for each part of speech in the lexicon, sharing the random source
+//
+// Could be used like
+//   generators := mnemonic.NewLexiconWordGenerators(lexicon, mnemonic.NewSeededRandomSource(42))
+func NewLexiconWordGenerators(lexicon *Lexicon, random RandomSource) []WordGeneratorV2 {
+	generators := []WordGeneratorV2{}
+
+	for _, partOfSpeech := range lexicon.PartsOfSpeech() {
+		generators = append(generators, NewLexiconWordGenerator(lexicon, partOfSpeech, random))
+	}
+
+	return generators
+}
+
+// GetFuncName the function name, which is the part of speech
+func (w *LexiconWordGenerator) GetFuncName() string {
+	return w.partOfSpeech
+}
+
+// Generate returns a random word beginning with a given letter, or an empty string if there isn't one
+func (w *LexiconWordGenerator) Generate(letter string) string {
+	word, err := w.GenerateWord(context.Background(), letter)
+
+	if err != nil {
+		return ""
+	}
+
+	return word.Lemma
+}
+
+// GenerateWord returns a random word beginning with a given letter, words with more weight are picked more often
+func (w *LexiconWordGenerator) GenerateWord(ctx context.Context, letter string) (Word, error) {
+	if err := ctx.Err(); err != nil {
+		return Word{}, err
+	}
+
+	entry, ok := w.lexicon.Pick(w.partOfSpeech, letter, w.random)
+
+	if !ok {
+		return Word{}, ErrNoWord
+	}
+
+	return entry.Word(), nil
+}
+
+// filtered returns a generator that picks from only the words keep keeps, filtering the pools once
+func (w *LexiconWordGenerator) filtered(keep WordFilter) WordGeneratorV2 {
+	entries := []LexiconEntry{}
+
+	for _, pool := range w.lexicon.pools[w.partOfSpeech] {
+		for _, entry := range pool {
+			if keep(entry.Word()) {
+				entries = append(entries, entry)
+			}
+		}
+	}
+
+	return NewLexiconWordGenerator(NewLexicon(entries...), w.partOfSpeech, w.random)
+}
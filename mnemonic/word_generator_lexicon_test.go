@@ -0,0 +1,84 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("LexiconWordGenerator", func() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a fruit", Source: "test"},
+		LexiconEntry{Lemma: "avocado", PartOfSpeech: "noun", Source: "test"},
+		LexiconEntry{Lemma: "amble", PartOfSpeech: "verb", Source: "test"},
+	)
+
+	It("Returns words of its part of speech beginning with the letter", func() {
+		generator := NewLexiconWordGenerator(lexicon, "noun", NewSeededRandomSource(1))
+
+		for i := 0; i < 10; i++ {
+			actual, err := generator.GenerateWord(context.Background(), "a")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Lemma).To(BeElementOf("apple", "avocado"))
+			Expect(actual.PartOfSpeech).To(Equal("noun"))
+		}
+	})
+	It("Returns the entry's metadata", func() {
+		generator := NewLexiconWordGenerator(NewLexicon(lexicon.Pool("noun", "a")[0]), "noun", NewSeededRandomSource(1))
+
+		actual, err := generator.GenerateWord(context.Background(), "a")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(Word{Lemma: "apple", PartOfSpeech: "noun", Gloss: "a fruit", Source: "test"}))
+	})
+	It("Returns ErrNoWord when there's no word", func() {
+		generator := NewLexiconWordGenerator(lexicon, "verb", NewSeededRandomSource(1))
+
+		_, err := generator.GenerateWord(context.Background(), "z")
+
+		Expect(err).To(Equal(ErrNoWord))
+		Expect(generator.Generate("z")).To(Equal(""))
+	})
+	It("Gives the same words for the same seed", func() {
+		first := NewLexiconWordGenerator(lexicon, "noun", NewSeededRandomSource(7))
+		second := NewLexiconWordGenerator(lexicon, "noun", NewSeededRandomSource(7))
+
+		for i := 0; i < 10; i++ {
+			Expect(first.Generate("a")).To(Equal(second.Generate("a")))
+		}
+	})
+	It("Makes a generator for each part of speech", func() {
+		generators := NewLexiconWordGenerators(lexicon, NewSeededRandomSource(1))
+
+		Expect(generators).To(HaveLen(2))
+		Expect(generators[0].GetFuncName()).To(Equal("noun"))
+		Expect(generators[1].GetFuncName()).To(Equal("verb"))
+	})
+})
+
+func ExampleLexiconWordGenerator_Generate() {
+	lexicon := NewLexicon(LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"})
+	generator := NewLexiconWordGenerator(lexicon, "noun", NewSeededRandomSource(42))
+
+	fmt.Println(generator.Generate("a"))
+	// Output: apple
+}
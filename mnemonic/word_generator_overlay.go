@@ -0,0 +1,104 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import "context"
+
+// chanceScale is how finely the chance of drawing from the overlay can be set
+const chanceScale = 10000
+
+// OverlayWordGenerator is a word generator that tries an overlay's words first some of the time
+type OverlayWordGenerator struct {
+	overlay WordGeneratorV2
+	base    WordGeneratorV2
+	chance  float64
+	random  RandomSource
+}
+
+// NewOverlayWordGenerator returns a generator that tries the overlay first with the given chance, between 0 and 1
+//
+// When the overlay isn't tried, or has no word for the letter, the base generator is used.
+//
+// Could be used like
+//   mnemonic.NewOverlayWordGenerator(
+//     mnemonic.NewLexiconWordGenerator(overlay.Lexicon(), "noun", random),
+//     mnemonic.NewLexiconWordGenerator(overlay.Apply(lexicon), "noun", random),
+//     0.25,
+//     random,
+//   )
+func NewOverlayWordGenerator(
+	overlay WordGeneratorV2,
+	base WordGeneratorV2,
+	chance float64,
+	random RandomSource,
+) *OverlayWordGenerator {
+	return &OverlayWordGenerator{overlay: overlay, base: base, chance: chance, random: random}
+}
+
+// NewOverlayWordGenerators returns a generator for each part of speech in the lexicon with the overlay applied,
+// trying the overlay's words first with the given chance
+//
+// Could be used like
+//   generators := mnemonic.NewOverlayWordGenerators(lexicon, overlay, 0.25, mnemonic.NewSeededRandomSource(42))
+func NewOverlayWordGenerators(
+	lexicon *Lexicon,
+	overlay *Overlay,
+	chance float64,
+	random RandomSource,
+) []WordGeneratorV2 {
+	merged := overlay.Apply(lexicon)
+	first := overlay.Lexicon()
+	generators := []WordGeneratorV2{}
+
+	for _, partOfSpeech := range merged.PartsOfSpeech() {
+		generators = append(generators, NewOverlayWordGenerator(
+			NewLexiconWordGenerator(first, partOfSpeech, random),
+			NewLexiconWordGenerator(merged, partOfSpeech, random),
+			chance,
+			random,
+		))
+	}
+
+	return generators
+}
+
+// GetFuncName the function name, which is the base generator's
+func (w *OverlayWordGenerator) GetFuncName() string {
+	return w.base.GetFuncName()
+}
+
+// GenerateWord returns a word from the overlay if it's tried and has one, otherwise from the base generator
+func (w *OverlayWordGenerator) GenerateWord(ctx context.Context, letter string) (Word, error) {
+	if w.chance > 0 && w.random.Intn(chanceScale) < int(w.chance*chanceScale) {
+		word, err := w.overlay.GenerateWord(ctx, letter)
+
+		if err != ErrNoWord {
+			return word, err
+		}
+	}
+
+	return w.base.GenerateWord(ctx, letter)
+}
+
+// filtered returns a generator that only picks words keep keeps, from the overlay and the base generator
+func (w *OverlayWordGenerator) filtered(keep WordFilter) WordGeneratorV2 {
+	return NewOverlayWordGenerator(
+		NewFilteredWordGenerator(w.overlay, keep),
+		NewFilteredWordGenerator(w.base, keep),
+		w.chance,
+		w.random,
+	)
+}
@@ -0,0 +1,75 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("OverlayWordGenerator", func() {
+	lexicon := NewLexicon(
+		LexiconEntry{Lemma: "apple", PartOfSpeech: "noun"},
+		LexiconEntry{Lemma: "banana", PartOfSpeech: "noun"},
+	)
+	overlay := NewOverlay(OverlayEntry{
+		Action:       OverlayAdd,
+		LexiconEntry: LexiconEntry{Lemma: "acme", PartOfSpeech: "noun"},
+	})
+
+	It("Always draws from the overlay first when the chance is 1", func() {
+		generators := NewOverlayWordGenerators(lexicon, overlay, 1, NewSeededRandomSource(1))
+
+		for i := 0; i < 10; i++ {
+			actual, err := generators[0].GenerateWord(context.Background(), "a")
+
+			Expect(err).NotTo(HaveOccurred())
+			Expect(actual.Lemma).To(Equal("acme"))
+		}
+	})
+	It("Falls back to the merged lexicon when the overlay has no word", func() {
+		generators := NewOverlayWordGenerators(lexicon, overlay, 1, NewSeededRandomSource(1))
+
+		actual, err := generators[0].GenerateWord(context.Background(), "b")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual.Lemma).To(Equal("banana"))
+	})
+	It("Still has the overlay's words when the chance is 0", func() {
+		generators := NewOverlayWordGenerators(lexicon, overlay, 0, NewSeededRandomSource(1))
+		picked := make(map[string]bool)
+
+		for i := 0; i < 50; i++ {
+			actual, _ := generators[0].GenerateWord(context.Background(), "a")
+			picked[actual.Lemma] = true
+		}
+
+		Expect(picked).To(Equal(map[string]bool{"acme": true, "apple": true}))
+	})
+	It("Is named after the base generator", func() {
+		generator := NewOverlayWordGenerator(
+			NewLexiconWordGenerator(overlay.Lexicon(), "noun", NewSeededRandomSource(1)),
+			NewLexiconWordGenerator(lexicon, "verb", NewSeededRandomSource(1)),
+			0.5,
+			NewSeededRandomSource(1),
+		)
+
+		Expect(generator.GetFuncName()).To(Equal("verb"))
+	})
+})
@@ -0,0 +1,91 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic_test
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	. "github.com/purplebooth/mnemonic/mnemonic"
+)
+
+var _ = Describe("WordGeneratorAdapter", func() {
+	It("Returns the word with the part of speech from the function name", func() {
+		adapter := NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj"))
+
+		actual, err := adapter.GenerateWord(context.Background(), "d")
+
+		Expect(err).NotTo(HaveOccurred())
+		Expect(actual).To(Equal(Word{Lemma: "dancing", PartOfSpeech: "adj"}))
+	})
+	It("Returns ErrNoWord for an empty word", func() {
+		adapter := NewWordGeneratorAdapter(NewStaticWordGenerator("", "adj"))
+
+		_, err := adapter.GenerateWord(context.Background(), "d")
+
+		Expect(err).To(Equal(ErrNoWord))
+	})
+	It("Returns the context error when cancelled", func() {
+		adapter := NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj"))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := adapter.GenerateWord(ctx, "d")
+
+		Expect(err).To(Equal(context.Canceled))
+	})
+	It("Keeps the function name", func() {
+		adapter := NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj"))
+
+		Expect(adapter.GetFuncName()).To(Equal("adj"))
+	})
+})
+
+var _ = Describe("RandomSource", func() {
+	Context("Seeded", func() {
+		It("Gives the same sequence for the same seed", func() {
+			first := NewSeededRandomSource(42)
+			second := NewSeededRandomSource(42)
+
+			for i := 0; i < 10; i++ {
+				Expect(first.Intn(1000)).To(Equal(second.Intn(1000)))
+			}
+		})
+		It("Gives a different sequence for a different seed", func() {
+			first := NewSeededRandomSource(42)
+			second := NewSeededRandomSource(43)
+
+			firstSequence := []int{}
+			secondSequence := []int{}
+
+			for i := 0; i < 10; i++ {
+				firstSequence = append(firstSequence, first.Intn(1000))
+				secondSequence = append(secondSequence, second.Intn(1000))
+			}
+
+			Expect(firstSequence).NotTo(Equal(secondSequence))
+		})
+	})
+})
+
+func ExampleNewWordGeneratorAdapter() {
+	adapter := NewWordGeneratorAdapter(NewStaticWordGenerator("dancing", "adj"))
+	word, _ := adapter.GenerateWord(context.Background(), "d")
+	fmt.Println(word.Lemma, word.PartOfSpeech)
+	// Output: dancing adj
+}
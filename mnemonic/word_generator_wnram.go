@@ -16,14 +16,41 @@
 package mnemonic
 
 import (
+	"os"
+
 	"github.com/lloyd/wnram"
-	"math/rand"
 )
 
+// wordNetSource is the source given to words that come from WordNet
+const wordNetSource = "wordnet"
+
+// LoadWordNet loads the WordNet dictionary files in a directory
+//
+// Could be used like
+//   wn, err := mnemonic.LoadWordNet("/tmp/dict")
+func LoadWordNet(dictDir string) (*wnram.Handle, error) {
+	// This library is bad and prints to stdout.
+	// We're swapping out stdout for /dev/null here then restoring it
+	devNull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+
+	if err != nil {
+		return nil, err
+	}
+
+	rescueStdout := os.Stdout
+	os.Stdout = devNull
+
+	wn, err := wnram.New(dictDir)
+
+	os.Stdout = rescueStdout
+	devNull.Close()
+
+	return wn, err
+}
+
 // WnramWordGenerator is a word generator that pulls random words from a WordNet dictionary
 type WnramWordGenerator struct {
-	wordList     map[string][]wnram.Lookup
-	partOfSpeech wnram.PartOfSpeech
+	*LexiconWordGenerator
 }
 
 // NewWnramWordGenerator returns a word generator that pulls random words from a WordNet dictionary
@@ -32,35 +59,38 @@ type WnramWordGenerator struct {
 //
 // Get dictionary files from http://wordnet.princeton.edu/
 //
+// Only the words for the part of speech are taken from the dictionary, use NewWnramWordGenerators to walk it once
+// for every part of speech. Words are drawn using random, so generators sharing a seeded source give the same words
+// each run.
+//
 // Could be used like
 //   wn, _ := wnram.New(dictDir)
-//   mnemonic.NewWnramWordGenerator(wn, wnram.Adjective)
-func NewWnramWordGenerator(wn *wnram.Handle, partOfSpeech wnram.PartOfSpeech) *WnramWordGenerator {
-	wordList := make(map[string][]wnram.Lookup)
-
-	wn.Iterate(wnram.PartOfSpeechList{partOfSpeech}, func(word wnram.Lookup) error {
-		firstLetter := getCharAt(word.Word(), 0)
-
-		wordList[firstLetter] = append(wordList[firstLetter], word)
-		return nil
-	})
-
-	return &WnramWordGenerator{wordList: wordList, partOfSpeech: partOfSpeech}
+//   mnemonic.NewWnramWordGenerator(wn, wnram.Adjective, mnemonic.NewSeededRandomSource(42))
+func NewWnramWordGenerator(wn *wnram.Handle, partOfSpeech wnram.PartOfSpeech, random RandomSource) *WnramWordGenerator {
+	return newWnramWordGenerator(newWordNetLexicon(wn, wnram.PartOfSpeechList{partOfSpeech}), partOfSpeech, random)
 }
 
-// GetFuncName the function name
-func (w *WnramWordGenerator) GetFuncName() string {
-	return w.partOfSpeech.String()
-}
+// NewWnramWordGenerators returns a word generator for each part of speech in a WordNet dictionary
+//
+// The dictionary is walked once into a lexicon that every generator is a view onto, and it's freed along with them.
+//
+// Could be used like
+//   wn, _ := wnram.New(dictDir)
+//   generators := mnemonic.NewWnramWordGenerators(wn, mnemonic.NewSeededRandomSource(42))
+func NewWnramWordGenerators(wn *wnram.Handle, random RandomSource) []WordGeneratorV2 {
+	lexicon := NewWordNetLexicon(wn)
+	generators := []WordGeneratorV2{}
 
-// Generate returns a random word beginning with a given letter
-func (w *WnramWordGenerator) Generate(letter string) string {
+	for _, partOfSpeech := range wordNetPartsOfSpeech() {
+		generators = append(generators, newWnramWordGenerator(lexicon, partOfSpeech, random))
+	}
 
-	for {
-		randomWord := w.wordList[letter][rand.Intn(len(w.wordList[letter]))].Word()
+	return generators
+}
 
-		if getCharAt(randomWord, 0) == letter {
-			return randomWord
-		}
+// newWnramWordGenerator returns a generator that's a view onto the words of one part of speech in a WordNet lexicon
+func newWnramWordGenerator(lexicon *Lexicon, partOfSpeech wnram.PartOfSpeech, random RandomSource) *WnramWordGenerator {
+	return &WnramWordGenerator{
+		LexiconWordGenerator: NewLexiconWordGenerator(lexicon, partOfSpeech.String(), random),
 	}
 }
@@ -0,0 +1,375 @@
+// Copyright (C) 2017 Billie Alice Thompson
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU General Public License for more details.
+//
+// You should have received a copy of the GNU General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package mnemonic
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// wordNetDataFiles are the files in a WordNet dictionary with the synsets in, and the part of speech of each
+var wordNetDataFiles = []struct {
+	name         string
+	partOfSpeech string
+}{
+	{"data.noun", "noun"},
+	{"data.verb", "verb"},
+	{"data.adj", "adj"},
+	{"data.adv", "adv"},
+}
+
+// wordNetPointerPartsOfSpeech maps the part of speech letters in WordNet data files to the names templates use
+var wordNetPointerPartsOfSpeech = map[string]string{
+	"n": "noun",
+	"v": "verb",
+	"a": "adj",
+	"s": "adj",
+	"r": "adv",
+}
+
+// wordNetSynset is a synset read straight from a WordNet data file, for what wnram doesn't tell us
+type wordNetSynset struct {
+	Offset       string
+	PartOfSpeech string
+	LexFilenum   int
+	Words        []string
+	Pointers     []wordNetPointer
+	Gloss        string
+}
+
+// wordNetPointer is a link from one synset to another
+type wordNetPointer struct {
+	Symbol       string
+	Offset       string
+	PartOfSpeech string
+}
+
+// ID returns an id for the synset that's unique across parts of speech
+func (s wordNetSynset) ID() string {
+	return synsetID(s.Offset, s.PartOfSpeech)
+}
+
+// ID returns the id of the synset the pointer points at
+func (p wordNetPointer) ID() string {
+	return synsetID(p.Offset, p.PartOfSpeech)
+}
+
+// synsetID joins a synset's offset and part of speech, as offsets are only unique within a data file
+func synsetID(offset string, partOfSpeech string) string {
+	return offset + "-" + partOfSpeech
+}
+
+// readWordNetData calls found with every synset in the data files of a WordNet dictionary
+func readWordNetData(dictDir string, found func(synset wordNetSynset)) error {
+	for _, dataFile := range wordNetDataFiles {
+		err := readWordNetDataFile(filepath.Join(dictDir, dataFile.name), dataFile.partOfSpeech, found)
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readWordNetDataFile calls found with every synset in one WordNet data file
+func readWordNetDataFile(path string, partOfSpeech string, found func(synset wordNetSynset)) error {
+	file, err := os.Open(path)
+
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+
+	for scanner.Scan() {
+		line++
+
+		// The licence at the top of the file is indented
+		if strings.HasPrefix(scanner.Text(), " ") || scanner.Text() == "" {
+			continue
+		}
+
+		synset, err := parseWordNetSynset(scanner.Text(), partOfSpeech)
+
+		if err != nil {
+			return fmt.Errorf("%s line %d: %v", path, line, err)
+		}
+
+		found(synset)
+	}
+
+	return scanner.Err()
+}
+
+// parseWordNetSynset reads a line of a data file, the layout is in WordNet's wndb(5) manual page
+func parseWordNetSynset(line string, partOfSpeech string) (wordNetSynset, error) {
+	parts := strings.SplitN(line, "|", 2)
+	fields := strings.Fields(parts[0])
+
+	if len(fields) < 4 {
+		return wordNetSynset{}, fmt.Errorf("too few fields")
+	}
+
+	synset := wordNetSynset{Offset: fields[0], PartOfSpeech: partOfSpeech}
+
+	if len(parts) == 2 {
+		synset.Gloss = strings.TrimSpace(parts[1])
+	}
+	lexFilenum, err := strconv.Atoi(fields[1])
+
+	if err != nil {
+		return wordNetSynset{}, fmt.Errorf("lexicographer file %q isn't a number", fields[1])
+	}
+
+	synset.LexFilenum = lexFilenum
+	wordCount, err := strconv.ParseInt(fields[3], 16, 32)
+
+	if err != nil {
+		return wordNetSynset{}, fmt.Errorf("word count %q isn't a hex number", fields[3])
+	}
+
+	next := 4
+
+	for i := 0; i < int(wordCount); i++ {
+		if next+1 >= len(fields) {
+			return wordNetSynset{}, fmt.Errorf("fewer words than the word count")
+		}
+
+		// Adjectives can have where they go in brackets after the word, like long(a)
+		word := fields[next]
+
+		if bracket := strings.Index(word, "("); bracket > 0 {
+			word = word[:bracket]
+		}
+
+		synset.Words = append(synset.Words, word)
+		next += 2
+	}
+
+	if next >= len(fields) {
+		return wordNetSynset{}, fmt.Errorf("missing pointer count")
+	}
+
+	pointerCount, err := strconv.Atoi(fields[next])
+
+	if err != nil {
+		return wordNetSynset{}, fmt.Errorf("pointer count %q isn't a number", fields[next])
+	}
+
+	next++
+
+	for i := 0; i < pointerCount; i++ {
+		if next+3 >= len(fields) {
+			return wordNetSynset{}, fmt.Errorf("fewer pointers than the pointer count")
+		}
+
+		synset.Pointers = append(synset.Pointers, wordNetPointer{
+			Symbol:       fields[next],
+			Offset:       fields[next+1],
+			PartOfSpeech: wordNetPointerPartsOfSpeech[fields[next+2]],
+		})
+		next += 4
+	}
+
+	return synset, nil
+}
+
+// wordNetUsageSymbol is the pointer from a synset to the usage domain it's a member of, like slang
+const wordNetUsageSymbol = ";u"
+
+// LoadWordNetUsages returns the usage domains of every synset in a WordNet dictionary that has one, as tags keyed by
+// synset id
+//
+// Only the senses in a usage domain get its tags, so the everyday sense of a word with a slang sense doesn't. Every
+// word of the usage domain's synset is a tag, so vulgarism and obscenity both are.
+//
+// Could be used like
+//   usages, err := mnemonic.LoadWordNetUsages("/tmp/dict")
+func LoadWordNetUsages(dictDir string) (map[string][]string, error) {
+	details, err := loadWordNetDetails(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return details.usages, nil
+}
+
+// LoadWordNetRelations returns the synsets each synset in a WordNet dictionary is linked to by the relations themes
+// follow, keyed by synset id
+//
+// Could be used like
+//   related, err := mnemonic.LoadWordNetRelations("/tmp/dict")
+func LoadWordNetRelations(dictDir string) (map[string][]string, error) {
+	details, err := loadWordNetDetails(dictDir)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return details.related, nil
+}
+
+// wordNetDetails is what the data files of a WordNet dictionary tell us that wnram doesn't
+type wordNetDetails struct {
+	// usages are the usage tags of the synsets in a usage domain, keyed by synset id
+	usages map[string][]string
+	// related are the synsets each synset is linked to by the relations themes follow, keyed by synset id
+	related map[string][]string
+	// synsets are keyed by part of speech and gloss, which is how a wnram word is matched to its synset
+	synsets map[string]wordNetSense
+	// firstSynsets are keyed by word and part of speech, for words whose gloss doesn't match
+	firstSynsets map[string]wordNetSense
+}
+
+// wordNetSense is the synset a word is in, and the lexicographer file it's from
+type wordNetSense struct {
+	SynsetID string
+	Domain   string
+}
+
+// loadWordNetDetails reads the usage domains, relations, synset ids and lexicographer files from a WordNet dictionary
+func loadWordNetDetails(dictDir string) (*wordNetDetails, error) {
+	details := &wordNetDetails{
+		usages:       make(map[string][]string),
+		related:      make(map[string][]string),
+		synsets:      make(map[string]wordNetSense),
+		firstSynsets: make(map[string]wordNetSense),
+	}
+	words := make(map[string][]string)
+	members := make(map[string][]string)
+
+	err := readWordNetData(dictDir, func(synset wordNetSynset) {
+		words[synset.ID()] = synset.Words
+		sense := wordNetSense{SynsetID: synset.ID(), Domain: lexicographerFile(synset.LexFilenum)}
+		details.synsets[glossKey(synset.PartOfSpeech, synset.Gloss)] = sense
+
+		for _, word := range synset.Words {
+			key := wordKey(contentKey(word), synset.PartOfSpeech)
+
+			if _, ok := details.firstSynsets[key]; !ok {
+				details.firstSynsets[key] = sense
+			}
+		}
+
+		for _, pointer := range synset.Pointers {
+			if pointer.Symbol == wordNetUsageSymbol {
+				members[pointer.ID()] = append(members[pointer.ID()], synset.ID())
+			}
+
+			if themeRelations[pointer.Symbol] {
+				details.related[synset.ID()] = append(details.related[synset.ID()], pointer.ID())
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	for domain, synsets := range members {
+		for _, synset := range synsets {
+			for _, usage := range words[domain] {
+				details.usages[synset] = appendTag(details.usages[synset], UsageTag(usage))
+			}
+		}
+	}
+
+	for key := range details.usages {
+		sort.Strings(details.usages[key])
+	}
+
+	return details, nil
+}
+
+// apply returns a new lexicon with the synset ids, domains, relations and usage tags set on the words
+//
+// Each entry is matched to its synset by its gloss, so only the senses in a usage domain are tagged with it.
+func (d *wordNetDetails) apply(lexicon *Lexicon) *Lexicon {
+	entries := lexicon.Entries()
+
+	for i := range entries {
+		sense, ok := d.synsets[glossKey(entries[i].PartOfSpeech, entries[i].Gloss)]
+
+		if !ok {
+			sense = d.firstSynsets[wordKey(contentKey(entries[i].Lemma), entries[i].PartOfSpeech)]
+		}
+
+		if entries[i].SynsetID == "" {
+			entries[i].SynsetID = sense.SynsetID
+		}
+
+		if entries[i].Domain == "" {
+			entries[i].Domain = sense.Domain
+		}
+
+		if entries[i].Related == nil {
+			entries[i].Related = d.related[entries[i].SynsetID]
+		}
+
+		if tags, ok := d.usages[entries[i].SynsetID]; ok {
+			entries[i].Tags = append(append([]string{}, entries[i].Tags...), tags...)
+		}
+	}
+
+	return NewLexicon(entries...)
+}
+
+// glossKey returns what a word is matched to its synset on, the part of speech and gloss
+func glossKey(partOfSpeech string, gloss string) string {
+	return partOfSpeech + "\x00" + strings.TrimSpace(gloss)
+}
+
+// lexicographerFiles are the names of WordNet's lexicographer files, in the order of their numbers
+var lexicographerFiles = []string{
+	"adj.all", "adj.pert", "adv.all", "noun.Tops", "noun.act", "noun.animal", "noun.artifact", "noun.attribute",
+	"noun.body", "noun.cognition", "noun.communication", "noun.event", "noun.feeling", "noun.food", "noun.group",
+	"noun.location", "noun.motive", "noun.object", "noun.person", "noun.phenomenon", "noun.plant",
+	"noun.possession", "noun.process", "noun.quantity", "noun.relation", "noun.shape", "noun.state",
+	"noun.substance", "noun.time", "verb.body", "verb.change", "verb.cognition", "verb.communication",
+	"verb.competition", "verb.consumption", "verb.contact", "verb.creation", "verb.emotion", "verb.motion",
+	"verb.perception", "verb.possession", "verb.social", "verb.stative", "verb.weather", "adj.ppl",
+}
+
+// lexicographerFile returns the name of a lexicographer file from its number, or nothing if it isn't known
+func lexicographerFile(number int) string {
+	if number < 0 || number >= len(lexicographerFiles) {
+		return ""
+	}
+
+	return lexicographerFiles[number]
+}
+
+// appendTag adds a tag to a list of tags, if it isn't already in it
+func appendTag(tags []string, tag string) []string {
+	for _, existing := range tags {
+		if existing == tag {
+			return tags
+		}
+	}
+
+	return append(tags, tag)
+}